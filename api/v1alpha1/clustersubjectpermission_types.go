@@ -0,0 +1,93 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +k8s:openapi-gen=true
+
+// ClusterSubjectPermission is the Schema for the clustersubjectpermissions API.
+// It is the cluster scoped counterpart of SubjectPermission, meant for cluster wide grants:
+// only users allowed to write cluster scoped objects can create one
+type ClusterSubjectPermission struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubjectPermissionSpec   `json:"spec,omitempty"`
+	Status SubjectPermissionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterSubjectPermissionList contains a list of ClusterSubjectPermission
+type ClusterSubjectPermissionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterSubjectPermission `json:"items"`
+}
+
+// SubjectPermissionObject is implemented by SubjectPermission and ClusterSubjectPermission,
+// so both kinds are reconciled by the same code
+// +kubebuilder:object:generate=false
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+type SubjectPermissionObject interface {
+	metav1.Object
+	runtime.Object
+	// GetSpec returns the spec of the object
+	GetSpec() *SubjectPermissionSpec
+	// GetStatus returns the status of the object
+	GetStatus() *SubjectPermissionStatus
+}
+
+var (
+	_ SubjectPermissionObject = &SubjectPermission{}
+	_ SubjectPermissionObject = &ClusterSubjectPermission{}
+)
+
+// GetSpec returns the spec of the SubjectPermission
+func (in *SubjectPermission) GetSpec() *SubjectPermissionSpec {
+	return &in.Spec
+}
+
+// GetStatus returns the status of the SubjectPermission
+func (in *SubjectPermission) GetStatus() *SubjectPermissionStatus {
+	return &in.Status
+}
+
+// GetSpec returns the spec of the ClusterSubjectPermission
+func (in *ClusterSubjectPermission) GetSpec() *SubjectPermissionSpec {
+	return &in.Spec
+}
+
+// GetStatus returns the status of the ClusterSubjectPermission
+func (in *ClusterSubjectPermission) GetStatus() *SubjectPermissionStatus {
+	return &in.Status
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterSubjectPermission{}, &ClusterSubjectPermissionList{})
+}
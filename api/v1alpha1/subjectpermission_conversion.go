@@ -0,0 +1,115 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LegacyClusterRoleBindingCreated is the condition type written by earlier versions of the operator
+	// for ClusterRoleBindings, it is replaced by Ready, Degraded and Progressing on the next reconcile
+	LegacyClusterRoleBindingCreated = "ClusterRoleBindingCreated"
+	// LegacyRoleBindingCreated is the condition type written by earlier versions of the operator
+	// for RoleBindings, it is replaced by Ready, Degraded and Progressing on the next reconcile
+	LegacyRoleBindingCreated = "RoleBindingCreated"
+)
+
+// legacyCondition is the condition format written by earlier versions of the operator
+// +kubebuilder:object:generate=false
+type legacyCondition struct {
+	Type               string      `json:"type,omitempty"`
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	Message            string      `json:"message,omitempty"`
+	ClusterRoleNames   []string    `json:"clusterRoleName,omitempty"`
+	Status             bool        `json:"status"`
+	State              string      `json:"state"`
+}
+
+// UnmarshalJSON decodes the status, converting conditions written by earlier versions of the operator,
+// which used a boolean status, into metav1.Conditions so existing objects can still be read
+func (in *SubjectPermissionStatus) UnmarshalJSON(data []byte) error {
+	type status SubjectPermissionStatus
+	raw := struct {
+		*status
+		Conditions []json.RawMessage `json:"conditions,omitempty"`
+	}{status: (*status)(in)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	in.Conditions = nil
+	for _, data := range raw.Conditions {
+		var probe struct {
+			Status json.RawMessage `json:"status"`
+		}
+		if err := json.Unmarshal(data, &probe); err != nil {
+			return err
+		}
+		if isJSONBool(probe.Status) {
+			var legacy legacyCondition
+			if err := json.Unmarshal(data, &legacy); err != nil {
+				return err
+			}
+			in.Conditions = append(in.Conditions, legacy.convert())
+			continue
+		}
+		var condition metav1.Condition
+		if err := json.Unmarshal(data, &condition); err != nil {
+			return err
+		}
+		in.Conditions = append(in.Conditions, condition)
+	}
+	return nil
+}
+
+// convert returns the metav1.Condition equivalent of the legacy condition.
+// The outcome was recorded in State, which the API server prunes once the new schema is installed,
+// the status is Unknown when it cannot be told anymore
+func (c legacyCondition) convert() metav1.Condition {
+	status := metav1.ConditionFalse
+	switch {
+	case c.Status && c.State == "Created":
+		status = metav1.ConditionTrue
+	case c.Status && c.State == "":
+		status = metav1.ConditionUnknown
+	}
+	reason := c.State
+	if reason == "" {
+		reason = "Unknown"
+	}
+	message := c.Message
+	if len(c.ClusterRoleNames) != 0 {
+		message += ": " + strings.Join(c.ClusterRoleNames, ", ")
+	}
+	return metav1.Condition{
+		Type:               c.Type,
+		Status:             status,
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// isJSONBool checks if the raw JSON value is a boolean
+func isJSONBool(value json.RawMessage) bool {
+	v := strings.TrimSpace(string(value))
+	return v == "true" || v == "false"
+}
@@ -0,0 +1,81 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUnmarshalLegacyConditions(t *testing.T) {
+	legacy := `{
+		"conditions": [
+			{"type": "ClusterRoleBindingCreated", "lastTransitionTime": "2024-01-02T03:04:05Z", "message": "Successfully created all ClusterRoleBindings", "clusterRoleName": ["admin", "view"], "status": true, "state": "Created"},
+			{"type": "RoleBindingCreated", "lastTransitionTime": "2024-01-02T03:04:05Z", "message": "Role for Permission does not exist", "clusterRoleName": ["edit"], "status": true, "state": "Failed"}
+		],
+		"subjects": [{"kind": "Group", "name": "dedicated-admins", "clusterRoleBindings": 2, "roleBindings": 0}]
+	}`
+
+	var status SubjectPermissionStatus
+	assert.NoError(t, json.Unmarshal([]byte(legacy), &status))
+	assert.Len(t, status.Conditions, 2)
+
+	assert.Equal(t, LegacyClusterRoleBindingCreated, status.Conditions[0].Type)
+	assert.Equal(t, metav1.ConditionTrue, status.Conditions[0].Status)
+	assert.Equal(t, "Created", status.Conditions[0].Reason)
+	assert.Equal(t, "Successfully created all ClusterRoleBindings: admin, view", status.Conditions[0].Message)
+	assert.Equal(t, 2024, status.Conditions[0].LastTransitionTime.Year())
+
+	assert.Equal(t, LegacyRoleBindingCreated, status.Conditions[1].Type)
+	assert.Equal(t, metav1.ConditionFalse, status.Conditions[1].Status)
+	assert.Equal(t, "Failed", status.Conditions[1].Reason)
+
+	assert.Len(t, status.Subjects, 1)
+	assert.Equal(t, 2, status.Subjects[0].ClusterRoleBindings)
+}
+
+func TestUnmarshalConditionsRoundTrip(t *testing.T) {
+	status := SubjectPermissionStatus{
+		ObservedGeneration: 4,
+		Conditions: []metav1.Condition{
+			{
+				Type:               ConditionReady,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: 4,
+				LastTransitionTime: metav1.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Reason:             ReasonReconciled,
+				Message:            "All ClusterRoleBindings and RoleBindings are in place",
+			},
+		},
+	}
+
+	data, err := json.Marshal(status)
+	assert.NoError(t, err)
+
+	var decoded SubjectPermissionStatus
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, status.ObservedGeneration, decoded.ObservedGeneration)
+	assert.Len(t, decoded.Conditions, 1)
+	assert.True(t, status.Conditions[0].LastTransitionTime.Equal(&decoded.Conditions[0].LastTransitionTime))
+	decoded.Conditions[0].LastTransitionTime = status.Conditions[0].LastTransitionTime
+	assert.Equal(t, status.Conditions, decoded.Conditions)
+}
+
+func TestUnmarshalSubjectPermissionWithLegacyStatus(t *testing.T) {
+	legacy := `{
+		"apiVersion": "managed.openshift.io/v1alpha1",
+		"kind": "SubjectPermission",
+		"metadata": {"name": "dedicated-admins", "namespace": "openshift-rbac-permissions"},
+		"spec": {"subjectKind": "Group", "subjectName": "dedicated-admins"},
+		"status": {"conditions": [{"type": "ClusterRoleBindingCreated", "lastTransitionTime": null, "status": true}]}
+	}`
+
+	var sp SubjectPermission
+	assert.NoError(t, json.Unmarshal([]byte(legacy), &sp))
+	assert.Equal(t, "dedicated-admins", sp.Spec.SubjectName)
+	assert.Len(t, sp.Status.Conditions, 1)
+	assert.Equal(t, metav1.ConditionUnknown, sp.Status.Conditions[0].Status)
+	assert.Equal(t, "Unknown", sp.Status.Conditions[0].Reason)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,76 +26,314 @@ import (
 // SubjectPermissionSpec defines the desired state of SubjectPermission
 type SubjectPermissionSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
-	// Kind of the Subject that is being granted permissions by the operator
-	SubjectKind string `json:"subjectKind"`
-	// Name of the Subject granted permissions by the operator
-	SubjectName string `json:"subjectName"`
-	// Namespace of the Subject granted permissions by the operator
+	// Kind of the Subject that is being granted permissions by the operator.
+	// Deprecated: use Subjects, kept for compatibility with existing SubjectPermissions
+	// +optional
+	SubjectKind string `json:"subjectKind,omitempty"`
+	// Name of the Subject granted permissions by the operator.
+	// Deprecated: use Subjects, kept for compatibility with existing SubjectPermissions
+	// +optional
+	SubjectName string `json:"subjectName,omitempty"`
+	// Namespace of the Subject granted permissions by the operator, required when SubjectKind is ServiceAccount.
+	// Deprecated: use Subjects, kept for compatibility with existing SubjectPermissions
 	// +optional
 	SubjectNamespace string `json:"subjectNamespace"`
+	// List of Subjects granted permissions by the operator, in addition to the Subject
+	// set with SubjectKind, SubjectName and SubjectNamespace. Every Subject gets its own bindings.
+	// +optional
+	Subjects []rbacv1.Subject `json:"subjects,omitempty"`
 	// List of permissions applied at Cluster scope
 	// +optional
 	ClusterPermissions []string `json:"clusterPermissions,omitempty"`
 	// List of permissions applied at Namespace scope
 	// +optional
 	Permissions []Permission `json:"permissions,omitempty"`
+	// ClusterRoles created and owned by the operator for the CR, which ClusterPermissions and Permissions can
+	// reference by name. They are deleted when removed from the list or when the CR is deleted
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=20
+	ClusterRoles []ManagedClusterRole `json:"clusterRoles,omitempty"`
+	// Mode of the SubjectPermission. In DryRun mode the bindings are not applied, the ClusterRoleBindings
+	// and RoleBindings that would be created, updated or deleted are reported in status.plan instead.
+	// Defaults to Enforce
+	// +optional
+	Mode SubjectPermissionMode `json:"mode,omitempty"`
+	// Time the bindings of the CR are revoked at, for time-bound access such as break-glass. The CR is kept
+	// and marked Expired. Cannot be set together with duration
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// How long the bindings of the CR are granted for, counted from its creation. The CR is kept and marked
+	// Expired once it elapsed. Cannot be set together with expiresAt
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// SubjectPermissionMode controls whether the bindings of a SubjectPermission are applied
+// +kubebuilder:validation:Enum=Enforce;DryRun
+type SubjectPermissionMode string
+
+const (
+	// ModeEnforce applies the bindings of the SubjectPermission
+	ModeEnforce SubjectPermissionMode = "Enforce"
+	// ModeDryRun only reports the bindings of the SubjectPermission that differ from the cluster
+	ModeDryRun SubjectPermissionMode = "DryRun"
+)
+
+// ManagedClusterRole defines a ClusterRole created and owned by the operator for a SubjectPermission
+type ManagedClusterRole struct {
+	// Name of the ClusterRole
+	Name string `json:"name"`
+	// Rules of the ClusterRole, filled in by the cluster when AggregationRule is set
+	// +optional
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+	// AggregationRule selects the ClusterRoles whose rules are aggregated into the ClusterRole
+	// +optional
+	AggregationRule *rbacv1.AggregationRule `json:"aggregationRule,omitempty"`
 }
 
 // Permission defines a Role that is bound to the Subject
 // Allowed in specific Namespaces
 type Permission struct {
-	// ClusterRoleName to bind to the Subject as a RoleBindings in allowed Namespaces
-	ClusterRoleName string `json:"clusterRoleName"`
+	// ClusterRoleName to bind to the Subject as a RoleBindings in allowed Namespaces.
+	// Exactly one of ClusterRoleName and RoleRef must be set
+	// +optional
+	ClusterRoleName string `json:"clusterRoleName,omitempty"`
+	// RoleRef references the ClusterRole, or the Role found in each allowed Namespace, to bind to the Subject.
+	// Exactly one of ClusterRoleName and RoleRef must be set
+	// +optional
+	RoleRef *PermissionRoleRef `json:"roleRef,omitempty"`
 	// NamespacesAllowedRegex representing allowed Namespaces
 	NamespacesAllowedRegex string `json:"namespacesAllowedRegex,omitempty"`
 	// NamespacesDeniedRegex representing denied Namespaces
 	NamespacesDeniedRegex string `json:"namespacesDeniedRegex,omitempty"`
+	// NamespaceSelector selects allowed Namespaces by label.
+	// A Namespace is allowed when it matches both NamespacesAllowedRegex and NamespaceSelector
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// NamespaceDenySelector selects denied Namespaces by label.
+	// A Namespace is denied when it matches either NamespacesDeniedRegex or NamespaceDenySelector
+	// +optional
+	NamespaceDenySelector *metav1.LabelSelector `json:"namespaceDenySelector,omitempty"`
+}
+
+// Kinds of the roles a Permission can bind
+const (
+	// RoleKindClusterRole binds a ClusterRole in each allowed Namespace
+	RoleKindClusterRole = "ClusterRole"
+	// RoleKindRole binds the Role of the same name that each allowed Namespace holds
+	RoleKindRole = "Role"
+)
+
+// PermissionRoleRef references the role a Permission binds to the Subject
+type PermissionRoleRef struct {
+	// Kind of the role, Role or ClusterRole
+	// +kubebuilder:validation:Enum=Role;ClusterRole
+	Kind string `json:"kind"`
+	// Name of the role. A Role is looked up by this name in every allowed Namespace
+	Name string `json:"name"`
+}
+
+// IsRole checks if the reference is to a Role, which lives in the Namespace of the RoleBinding
+func (r PermissionRoleRef) IsRole() bool {
+	return r.Kind == RoleKindRole
+}
+
+// String returns the name of a ClusterRole, or Role/<name> for a Role, which identifies the role within a
+// SubjectPermission
+func (r PermissionRoleRef) String() string {
+	if r.IsRole() {
+		return RoleKindRole + "/" + r.Name
+	}
+	return r.Name
+}
+
+// Role returns the role bound by the Permission, the ClusterRole of ClusterRoleName unless RoleRef is set
+func (p Permission) Role() PermissionRoleRef {
+	if p.RoleRef != nil {
+		return *p.RoleRef
+	}
+	return PermissionRoleRef{Kind: RoleKindClusterRole, Name: p.ClusterRoleName}
 }
 
 // +k8s:openapi-gen=true
 // SubjectPermissionStatus defines the observed state of SubjectPermission
 type SubjectPermissionStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
+	// ObservedGeneration is the most recent generation of the spec reconciled by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// List of conditions for the CR
-	Conditions []Condition `json:"conditions,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+	// Bindings in place for each Subject of the CR
+	// +optional
+	Subjects []SubjectStatus `json:"subjects,omitempty"`
+	// Names of the ClusterRoleBindings in place for the CR
+	// +optional
+	ClusterRoleBindings []string `json:"clusterRoleBindings,omitempty"`
+	// Names of the ClusterRoles in place that the operator created for the CR
+	// +optional
+	ClusterRoles []string `json:"clusterRoles,omitempty"`
+	// Names of the ClusterRoles of the CR that do not exist, they are bound once they are created
+	// +optional
+	MissingClusterRoles []string `json:"missingClusterRoles,omitempty"`
+	// Names of the ClusterRoles of the CR that the operator grant policy denies, so they are not bound
+	// +optional
+	DeniedClusterRoles []string `json:"deniedClusterRoles,omitempty"`
+	// RoleBindings in place for each ClusterRole of the Permissions of the CR
+	// +optional
+	Permissions []PermissionStatus `json:"permissions,omitempty"`
+	// Bindings that would change if the CR was enforced, only set in DryRun mode
+	// +optional
+	Plan *SubjectPermissionPlan `json:"plan,omitempty"`
+	// Time the bindings of the CR are revoked at, from expiresAt or duration
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
 
-// Condition defines a single condition of running the operator against an instance of the SubjectPermission CR
-type Condition struct {
-	// Type is the type of the condition
-	Type SubjectPermissionType `json:"type,omitempty"`
-	// LastTransitionTime is the last time this condition was active for the CR
-	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
-	// Message related to the condition
+// SubjectPermissionPlan reports the bindings a DryRun SubjectPermission would change on the cluster
+type SubjectPermissionPlan struct {
+	// Changes to the ClusterRoleBindings, listed by name
+	ClusterRoleBindings BindingChanges `json:"clusterRoleBindings"`
+	// Changes to the RoleBindings, listed as <namespace>/<name>
+	RoleBindings BindingChanges `json:"roleBindings"`
+	// Changes to the ClusterRoles created for the CR, listed by name
 	// +optional
-	Message string `json:"message,omitempty"`
-	// ClusterRoleName in which this condition is true
-	ClusterRoleNames []string `json:"clusterRoleName,omitempty"`
-	// Flag to indicate if condition status is currently active
-	Status bool `json:"status"`
-	// State that this condition represents
-	State SubjectPermissionState `json:"state"`
+	ClusterRoles BindingChanges `json:"clusterRoles,omitempty"`
 }
 
-// SubjectPermissionState defines various states a SubjectPermission CR can be in
-type SubjectPermissionState string
+// BindingChanges counts the bindings that would be created, updated or deleted,
+// and lists the first of them by name, capped at MaxPlannedBindings entries each
+type BindingChanges struct {
+	// Number of bindings that would be created
+	Create int `json:"create"`
+	// Number of bindings whose subjects, roleRef or ownership metadata would be restored
+	Update int `json:"update"`
+	// Number of bindings that would be deleted
+	Delete int `json:"delete"`
+	// Bindings that would be created
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	ToCreate []string `json:"toCreate,omitempty"`
+	// Bindings that would be updated
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	ToUpdate []string `json:"toUpdate,omitempty"`
+	// Bindings that would be deleted
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	ToDelete []string `json:"toDelete,omitempty"`
+}
 
-// SubjectPermissionType defines various type a SubjectPermission CR can be in
-type SubjectPermissionType string
+// MaxPlannedBindings is the number of bindings listed for each kind of change in the plan
+const MaxPlannedBindings = 50
+
+// PermissionStatus reports the RoleBindings in place for the ClusterRole, or the Role, of a Permission
+type PermissionStatus struct {
+	// ClusterRoleName of the Permission, or the name of its Role when Kind is Role
+	ClusterRoleName string `json:"clusterRoleName"`
+	// Kind of the role of the Permission, only set to Role for a Permission binding a Role
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Number of RoleBindings in place for the ClusterRole, across every allowed Namespace and Subject
+	RoleBindings int `json:"roleBindings"`
+	// Namespaces in which the RoleBindings could not be applied, capped at MaxFailedNamespaces entries
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	FailedNamespaces []NamespaceFailure `json:"failedNamespaces,omitempty"`
+	// Namespaces matched by the Permission that the operator grant policy protects, so they receive no RoleBinding.
+	// Sorted and capped at MaxProtectedNamespaces entries
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	ProtectedNamespaces []string `json:"protectedNamespaces,omitempty"`
+	// Namespaces matched by the Permission that do not hold its Role, so they receive no RoleBinding until the Role
+	// is created. Sorted and capped at MaxMissingRoleNamespaces entries
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	MissingRoleNamespaces []string `json:"missingRoleNamespaces,omitempty"`
+}
+
+// Role returns the role the status reports on
+func (s PermissionStatus) Role() PermissionRoleRef {
+	if s.Kind == RoleKindRole {
+		return PermissionRoleRef{Kind: RoleKindRole, Name: s.ClusterRoleName}
+	}
+	return PermissionRoleRef{Kind: RoleKindClusterRole, Name: s.ClusterRoleName}
+}
+
+// NamespaceFailure reports why the RoleBindings of a Permission could not be applied in a Namespace
+type NamespaceFailure struct {
+	// Namespace the RoleBindings could not be applied in
+	Namespace string `json:"namespace"`
+	// Reason the RoleBindings could not be applied
+	Reason string `json:"reason"`
+}
+
+// MaxFailedNamespaces is the number of failing Namespaces reported for each Permission,
+// which keeps the status of SubjectPermissions granting permissions in many Namespaces bounded
+const MaxFailedNamespaces = 10
+
+// MaxProtectedNamespaces is the number of protected Namespaces reported for each Permission
+const MaxProtectedNamespaces = 50
+
+// MaxMissingRoleNamespaces is the number of Namespaces missing the Role reported for each Permission
+const MaxMissingRoleNamespaces = 50
+
+// SubjectStatus reports the bindings in place for a single Subject of the SubjectPermission
+type SubjectStatus struct {
+	// Kind of the Subject
+	Kind string `json:"kind"`
+	// Name of the Subject
+	Name string `json:"name"`
+	// Namespace of the Subject
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Number of ClusterRoleBindings in place for the Subject
+	ClusterRoleBindings int `json:"clusterRoleBindings"`
+	// Number of RoleBindings in place for the Subject
+	RoleBindings int `json:"roleBindings"`
+}
 
 const (
-	// ClusterRoleBindingCreated const for ClusterRoleBindingCreated status
-	ClusterRoleBindingCreated SubjectPermissionType = "ClusterRoleBindingCreated"
-	// RoleBindingCreated const for RoleBindingCreated status
-	RoleBindingCreated SubjectPermissionType = "RoleBindingCreated"
-	// SubjectPermissionStateCreated const for Created state
-	SubjectPermissionStateCreated SubjectPermissionState = "Created"
-	// SubjectPermissionStateFailed const for Failed state
-	SubjectPermissionStateFailed SubjectPermissionState = "Failed"
+	// ConditionReady is True when every binding requested by the SubjectPermission is in place
+	ConditionReady = "Ready"
+	// ConditionDegraded is True when the SubjectPermission cannot be fully reconciled
+	ConditionDegraded = "Degraded"
+	// ConditionProgressing is True while the bindings are being brought in line with the spec
+	ConditionProgressing = "Progressing"
+
+	// ReasonReconciled is used when every binding is in place
+	ReasonReconciled = "Reconciled"
+	// ReasonInvalidSpec is used when the spec failed validation
+	ReasonInvalidSpec = "InvalidSpec"
+	// ReasonClusterRoleNotFound is used when a referenced ClusterRole does not exist
+	ReasonClusterRoleNotFound = "ClusterRoleNotFound"
+	// ReasonRoleNotFound is used when some allowed Namespaces do not hold the Role of a Permission
+	ReasonRoleNotFound = "RoleNotFound"
+	// ReasonDryRun is used when the SubjectPermission is in DryRun mode and its bindings are not applied
+	ReasonDryRun = "DryRun"
+	// ReasonBindingsFailed is used when some ClusterRoleBindings or RoleBindings could not be applied or revoked
+	ReasonBindingsFailed = "BindingsFailed"
+	// ReasonPolicyViolation is used when the operator grant policy withholds some of the requested bindings
+	ReasonPolicyViolation = "PolicyViolation"
+	// ReasonClusterRoleConflict is used when a ClusterRole defined by the SubjectPermission already exists
+	// and was not created for it
+	ReasonClusterRoleConflict = "ClusterRoleConflict"
+	// ReasonExpired is used when the expiration of the SubjectPermission passed and its bindings were revoked
+	ReasonExpired = "Expired"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +k8s:openapi-gen=true
 
 // SubjectPermission is the Schema for the subjectpermissions API
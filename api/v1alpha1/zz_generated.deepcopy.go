@@ -5,26 +5,138 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Condition) DeepCopyInto(out *Condition) {
+func (in *BindingChanges) DeepCopyInto(out *BindingChanges) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	if in.ClusterRoleNames != nil {
-		in, out := &in.ClusterRoleNames, &out.ClusterRoleNames
+	if in.ToCreate != nil {
+		in, out := &in.ToCreate, &out.ToCreate
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToUpdate != nil {
+		in, out := &in.ToUpdate, &out.ToUpdate
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToDelete != nil {
+		in, out := &in.ToDelete, &out.ToDelete
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
-func (in *Condition) DeepCopy() *Condition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BindingChanges.
+func (in *BindingChanges) DeepCopy() *BindingChanges {
 	if in == nil {
 		return nil
 	}
-	out := new(Condition)
+	out := new(BindingChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSubjectPermission) DeepCopyInto(out *ClusterSubjectPermission) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSubjectPermission.
+func (in *ClusterSubjectPermission) DeepCopy() *ClusterSubjectPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSubjectPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSubjectPermission) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSubjectPermissionList) DeepCopyInto(out *ClusterSubjectPermissionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterSubjectPermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSubjectPermissionList.
+func (in *ClusterSubjectPermissionList) DeepCopy() *ClusterSubjectPermissionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSubjectPermissionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterSubjectPermissionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedClusterRole) DeepCopyInto(out *ManagedClusterRole) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]v1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AggregationRule != nil {
+		in, out := &in.AggregationRule, &out.AggregationRule
+		*out = new(v1.AggregationRule)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedClusterRole.
+func (in *ManagedClusterRole) DeepCopy() *ManagedClusterRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedClusterRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceFailure) DeepCopyInto(out *NamespaceFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceFailure.
+func (in *NamespaceFailure) DeepCopy() *NamespaceFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceFailure)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -32,6 +144,21 @@ func (in *Condition) DeepCopy() *Condition {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Permission) DeepCopyInto(out *Permission) {
 	*out = *in
+	if in.RoleRef != nil {
+		in, out := &in.RoleRef, &out.RoleRef
+		*out = new(PermissionRoleRef)
+		**out = **in
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceDenySelector != nil {
+		in, out := &in.NamespaceDenySelector, &out.NamespaceDenySelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Permission.
@@ -44,6 +171,51 @@ func (in *Permission) DeepCopy() *Permission {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionRoleRef) DeepCopyInto(out *PermissionRoleRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionRoleRef.
+func (in *PermissionRoleRef) DeepCopy() *PermissionRoleRef {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionRoleRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PermissionStatus) DeepCopyInto(out *PermissionStatus) {
+	*out = *in
+	if in.FailedNamespaces != nil {
+		in, out := &in.FailedNamespaces, &out.FailedNamespaces
+		*out = make([]NamespaceFailure, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProtectedNamespaces != nil {
+		in, out := &in.ProtectedNamespaces, &out.ProtectedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingRoleNamespaces != nil {
+		in, out := &in.MissingRoleNamespaces, &out.MissingRoleNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PermissionStatus.
+func (in *PermissionStatus) DeepCopy() *PermissionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PermissionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubjectPermission) DeepCopyInto(out *SubjectPermission) {
 	*out = *in
@@ -103,9 +275,32 @@ func (in *SubjectPermissionList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectPermissionPlan) DeepCopyInto(out *SubjectPermissionPlan) {
+	*out = *in
+	in.ClusterRoleBindings.DeepCopyInto(&out.ClusterRoleBindings)
+	in.RoleBindings.DeepCopyInto(&out.RoleBindings)
+	in.ClusterRoles.DeepCopyInto(&out.ClusterRoles)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectPermissionPlan.
+func (in *SubjectPermissionPlan) DeepCopy() *SubjectPermissionPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectPermissionPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubjectPermissionSpec) DeepCopyInto(out *SubjectPermissionSpec) {
 	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
 	if in.ClusterPermissions != nil {
 		in, out := &in.ClusterPermissions, &out.ClusterPermissions
 		*out = make([]string, len(*in))
@@ -114,7 +309,25 @@ func (in *SubjectPermissionSpec) DeepCopyInto(out *SubjectPermissionSpec) {
 	if in.Permissions != nil {
 		in, out := &in.Permissions, &out.Permissions
 		*out = make([]Permission, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterRoles != nil {
+		in, out := &in.ClusterRoles, &out.ClusterRoles
+		*out = make([]ManagedClusterRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
@@ -133,11 +346,52 @@ func (in *SubjectPermissionStatus) DeepCopyInto(out *SubjectPermissionStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]SubjectStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterRoleBindings != nil {
+		in, out := &in.ClusterRoleBindings, &out.ClusterRoleBindings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterRoles != nil {
+		in, out := &in.ClusterRoles, &out.ClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingClusterRoles != nil {
+		in, out := &in.MissingClusterRoles, &out.MissingClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedClusterRoles != nil {
+		in, out := &in.DeniedClusterRoles, &out.DeniedClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]PermissionStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(SubjectPermissionPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectPermissionStatus.
@@ -149,3 +403,18 @@ func (in *SubjectPermissionStatus) DeepCopy() *SubjectPermissionStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubjectStatus) DeepCopyInto(out *SubjectStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubjectStatus.
+func (in *SubjectStatus) DeepCopy() *SubjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -28,28 +28,40 @@ func schema_openshift_rbac_permissions_operator_api_v1alpha1_SubjectPermissionSp
 				Properties: map[string]spec.Schema{
 					"subjectKind": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Important: Run \"make\" to regenerate code after modifying this file Kind of the Subject that is being granted permissions by the operator",
-							Default:     "",
+							Description: "Important: Run \"make\" to regenerate code after modifying this file Kind of the Subject that is being granted permissions by the operator. Deprecated: use Subjects, kept for compatibility with existing SubjectPermissions",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 					"subjectName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Name of the Subject granted permissions by the operator",
-							Default:     "",
+							Description: "Name of the Subject granted permissions by the operator. Deprecated: use Subjects, kept for compatibility with existing SubjectPermissions",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 					"subjectNamespace": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Namespace of the Subject granted permissions by the operator",
+							Description: "Namespace of the Subject granted permissions by the operator, required when SubjectKind is ServiceAccount. Deprecated: use Subjects, kept for compatibility with existing SubjectPermissions",
 							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
+					"subjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "List of Subjects granted permissions by the operator, in addition to the Subject set with SubjectKind, SubjectName and SubjectNamespace. Every Subject gets its own bindings.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/rbac/v1.Subject"),
+									},
+								},
+							},
+						},
+					},
 					"clusterPermissions": {
 						SchemaProps: spec.SchemaProps{
 							Description: "List of permissions applied at Cluster scope",
@@ -79,12 +91,52 @@ func schema_openshift_rbac_permissions_operator_api_v1alpha1_SubjectPermissionSp
 							},
 						},
 					},
+					"clusterRoles": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterRoles created and owned by the operator for the CR, which ClusterPermissions and Permissions can reference by name. They are deleted when removed from the list or when the CR is deleted",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/openshift/rbac-permissions-operator/api/v1alpha1.ManagedClusterRole"),
+									},
+								},
+							},
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Mode of the SubjectPermission. In DryRun mode the bindings are not applied, the ClusterRoleBindings and RoleBindings that would be created, updated or deleted are reported in status.plan instead. Defaults to Enforce",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"expiresAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time the bindings of the CR are revoked at, for time-bound access such as break-glass. The CR is kept and marked Expired. Cannot be set together with duration",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "How long the bindings of the CR are granted for, counted from its creation. The CR is kept and marked Expired once it elapsed. Cannot be set together with expiresAt",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 				},
-				Required: []string{"subjectKind", "subjectName"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/openshift/rbac-permissions-operator/api/v1alpha1.Permission"},
+			"github.com/openshift/rbac-permissions-operator/api/v1alpha1.ManagedClusterRole", "github.com/openshift/rbac-permissions-operator/api/v1alpha1.Permission", "k8s.io/api/rbac/v1.Subject", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -95,24 +147,141 @@ func schema_openshift_rbac_permissions_operator_api_v1alpha1_SubjectPermissionSt
 				Description: "SubjectPermissionStatus defines the observed state of SubjectPermission",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Important: Run \"make\" to regenerate code after modifying this file ObservedGeneration is the most recent generation of the spec reconciled by the operator",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"type",
+								},
+								"x-kubernetes-list-type":       "map",
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
 						SchemaProps: spec.SchemaProps{
-							Description: "Important: Run \"make\" to regenerate code after modifying this file List of conditions for the CR",
+							Description: "List of conditions for the CR",
 							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
 										Default: map[string]interface{}{},
-										Ref:     ref("github.com/openshift/rbac-permissions-operator/api/v1alpha1.Condition"),
+										Ref:     ref("k8s.io/apimachinery/pkg/apis/meta/v1.Condition"),
 									},
 								},
 							},
 						},
 					},
+					"subjects": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bindings in place for each Subject of the CR",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/openshift/rbac-permissions-operator/api/v1alpha1.SubjectStatus"),
+									},
+								},
+							},
+						},
+					},
+					"clusterRoleBindings": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Names of the ClusterRoleBindings in place for the CR",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"clusterRoles": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Names of the ClusterRoles in place that the operator created for the CR",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"missingClusterRoles": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Names of the ClusterRoles of the CR that do not exist, they are bound once they are created",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"deniedClusterRoles": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Names of the ClusterRoles of the CR that the operator grant policy denies, so they are not bound",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"permissions": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RoleBindings in place for each ClusterRole of the Permissions of the CR",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/openshift/rbac-permissions-operator/api/v1alpha1.PermissionStatus"),
+									},
+								},
+							},
+						},
+					},
+					"plan": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Bindings that would change if the CR was enforced, only set in DryRun mode",
+							Ref:         ref("github.com/openshift/rbac-permissions-operator/api/v1alpha1.SubjectPermissionPlan"),
+						},
+					},
+					"expiresAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time the bindings of the CR are revoked at, from expiresAt or duration",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/openshift/rbac-permissions-operator/api/v1alpha1.Condition"},
+			"github.com/openshift/rbac-permissions-operator/api/v1alpha1.PermissionStatus", "github.com/openshift/rbac-permissions-operator/api/v1alpha1.SubjectPermissionPlan", "github.com/openshift/rbac-permissions-operator/api/v1alpha1.SubjectStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
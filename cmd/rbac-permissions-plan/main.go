@@ -0,0 +1,168 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// rbac-permissions-plan evaluates SubjectPermission and ClusterSubjectPermission manifests against a list of namespaces, without a cluster,
+// and prints the bindings the operator would apply for them. It exits with status 1 when a SubjectPermission is invalid.
+// The grant policy of the operator ConfigMap, such as its protected namespaces, is applied when given.
+//
+//	rbac-permissions-plan --namespaces namespaces.yaml [--policy configmap.yaml] [--output table|json] subjectpermission.yaml...
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	controllerutil "github.com/openshift/rbac-permissions-operator/pkg/controllerutils"
+)
+
+func main() {
+	var namespacesFile string
+	var policyFile string
+	var output string
+	flag.StringVar(&namespacesFile, "namespaces", "", "File with the namespaces to evaluate against, either manifests such as "+
+		"the output of `oc get namespaces -o yaml` or one namespace name per line. Use - for stdin.")
+	flag.StringVar(&policyFile, "policy", "", "File with the rbac-permissions-operator ConfigMap holding the grant policy, "+
+		"such as the output of `oc get configmap rbac-permissions-operator -o yaml`. Everything is granted without it.")
+	flag.StringVar(&output, "output", "table", "Output format, table or json.")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s --namespaces FILE [--policy FILE] [--output table|json] MANIFEST...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if namespacesFile == "" || flag.NArg() == 0 || (output != "table" && output != "json") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ok, err := run(namespacesFile, policyFile, flag.Args(), output, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// run prints the plan of every SubjectPermission of the manifests, it returns false when one is invalid
+func run(namespacesFile, policyFile string, paths []string, output string, w io.Writer) (bool, error) {
+	nsList, err := readFile(namespacesFile, ReadNamespaces)
+	if err != nil {
+		return false, fmt.Errorf("failed to read namespaces from %s: %w", namespacesFile, err)
+	}
+	var policy *controllerutil.GrantPolicy
+	if policyFile != "" {
+		if policy, err = readFile(policyFile, ReadGrantPolicy); err != nil {
+			return false, fmt.Errorf("failed to read the grant policy from %s: %w", policyFile, err)
+		}
+	}
+
+	plans := []Plan{}
+	for _, path := range paths {
+		files, err := manifestFiles(path)
+		if err != nil {
+			return false, err
+		}
+		for _, file := range files {
+			subjectPermissions, err := readFile(file, ReadSubjectPermissions)
+			if err != nil {
+				return false, fmt.Errorf("failed to read SubjectPermissions from %s: %w", file, err)
+			}
+			for i := range subjectPermissions {
+				plans = append(plans, PlanSubjectPermission(subjectPermissions[i], nsList, policy))
+			}
+		}
+	}
+
+	ok := true
+	for _, plan := range plans {
+		if len(plan.Errors) != 0 {
+			ok = false
+		}
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return ok, encoder.Encode(plans)
+	}
+	return ok, printTable(w, plans)
+}
+
+// printTable prints the bindings of the plans, one per line, and the problems of invalid SubjectPermissions
+func printTable(w io.Writer, plans []Plan) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SUBJECTPERMISSION\tKIND\tNAMESPACE\tNAME\tCLUSTERROLE\tSUBJECT")
+	for _, plan := range plans {
+		for _, problem := range plan.Errors {
+			fmt.Fprintf(tw, "%s\tERROR\t\t%s\t\t\n", plan.SubjectPermission, problem)
+		}
+		for _, binding := range plan.Bindings {
+			namespace := binding.Namespace
+			if namespace == "" {
+				namespace = "*"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", plan.SubjectPermission, binding.Kind, namespace, binding.Name, binding.ClusterRole, binding.Subject)
+		}
+	}
+	return tw.Flush()
+}
+
+// manifestFiles returns the path itself, or the YAML and JSON files found under a directory
+func manifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	var files []string
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch filepath.Ext(file) {
+		case ".yaml", ".yml", ".json":
+			if !d.IsDir() {
+				files = append(files, file)
+			}
+		}
+		return nil
+	})
+	return files, err
+}
+
+// readFile decodes the file, or stdin for -, with the reader function
+func readFile[T any](path string, read func(io.Reader) (T, error)) (T, error) {
+	if path == "-" {
+		return read(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	defer f.Close()
+	return read(f)
+}
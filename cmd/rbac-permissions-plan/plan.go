@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	controllers "github.com/openshift/rbac-permissions-operator/controllers/subjectpermission"
+	controllerutil "github.com/openshift/rbac-permissions-operator/pkg/controllerutils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// PlannedBinding is a ClusterRoleBinding or RoleBinding the operator would apply for a SubjectPermission
+type PlannedBinding struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// ClusterRole is the granted ClusterRole, or Role/<name> for a Role of the namespace
+	ClusterRole string `json:"clusterRole"`
+	Subject     string `json:"subject"`
+}
+
+// Plan is the outcome of evaluating a SubjectPermission against a list of Namespaces
+type Plan struct {
+	SubjectPermission string           `json:"subjectPermission"`
+	Errors            []string         `json:"errors,omitempty"`
+	Bindings          []PlannedBinding `json:"bindings"`
+}
+
+// PlanSubjectPermission returns the bindings the operator would apply for the SubjectPermission or
+// ClusterSubjectPermission, in the same way as the controllers do, without checking that the ClusterRoles exist.
+// The bindings withheld by the grant policy are left out, a nil policy grants everything.
+// An invalid SubjectPermission is reported in the errors of the plan instead of its bindings
+func PlanSubjectPermission(sp managedv1alpha1.SubjectPermissionObject, nsList *corev1.NamespaceList, policy *controllerutil.GrantPolicy) Plan {
+	plan := Plan{SubjectPermission: controllerutil.OwnerIndexValue(sp)}
+	if err := controllers.ValidateSubjectPermission(sp); err != nil {
+		plan.Errors = errorMessages(err)
+		return plan
+	}
+
+	// terminating namespaces are skipped by the controllers
+	activeNsList := &corev1.NamespaceList{}
+	for i := range nsList.Items {
+		if controllerutil.ValidateNamespace(&nsList.Items[i]) {
+			activeNsList.Items = append(activeNsList.Items, nsList.Items[i])
+		}
+	}
+
+	subjects := controllerutil.SubjectsOf(sp.GetSpec())
+	for _, clusterRoleName := range sp.GetSpec().ClusterPermissions {
+		if !policy.PermitsClusterRoleOf(sp.GetSpec(), clusterRoleName) {
+			continue
+		}
+		for _, subject := range subjects {
+			crb := controllers.NewClusterRoleBinding(sp, clusterRoleName, subject.Name, subject.Namespace, subject.Kind)
+			plan.Bindings = append(plan.Bindings, PlannedBinding{
+				Kind:        "ClusterRoleBinding",
+				Name:        crb.Name,
+				ClusterRole: clusterRoleName,
+				Subject:     controllerutil.SubjectKey(subject),
+			})
+		}
+	}
+	for _, permission := range sp.GetSpec().Permissions {
+		role := permission.Role()
+		// the grant policy only limits ClusterRoles, Roles are written by the namespace owners
+		if !role.IsRole() && !policy.PermitsNamespacedClusterRoleOf(sp.GetSpec(), role.Name) {
+			continue
+		}
+		safeList, err := controllerutil.GenerateSafeListForPermission(permission, activeNsList, policy)
+		if err != nil {
+			plan.Errors = append(plan.Errors, fmt.Sprintf("Permission for %s: %v", role, err))
+			continue
+		}
+		for _, ns := range safeList {
+			for _, subject := range subjects {
+				rb := controllerutil.NewRoleBinding(sp, role, subject.Name, subject.Namespace, subject.Kind, ns)
+				plan.Bindings = append(plan.Bindings, PlannedBinding{
+					Kind:        "RoleBinding",
+					Namespace:   ns,
+					Name:        rb.Name,
+					ClusterRole: role.String(),
+					Subject:     controllerutil.SubjectKey(subject),
+				})
+			}
+		}
+	}
+
+	// cluster wide bindings first, then the RoleBindings grouped by namespace
+	slices.SortStableFunc(plan.Bindings, func(a, b PlannedBinding) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return plan
+}
+
+// errorMessages splits an aggregated validation error into its messages
+func errorMessages(err error) []string {
+	var messages []string
+	if agg, ok := err.(utilerrors.Aggregate); ok {
+		for _, e := range agg.Errors() {
+			messages = append(messages, e.Error())
+		}
+		return messages
+	}
+	return []string{err.Error()}
+}
+
+// manifest holds a decoded document until its kind is known
+type manifest struct {
+	metav1.TypeMeta `json:",inline"`
+	Items           []json.RawMessage `json:"items,omitempty"`
+}
+
+// decodeManifests decodes every YAML or JSON document of the reader, expanding Lists,
+// and calls visit with the kind and the raw JSON of each object
+func decodeManifests(r io.Reader, visit func(kind string, data []byte) error) error {
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var data json.RawMessage
+		if err := decoder.Decode(&data); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := visitManifest(data, visit); err != nil {
+			return err
+		}
+	}
+}
+
+// visitManifest calls visit for the object, or for every item of a List
+func visitManifest(data []byte, visit func(kind string, data []byte) error) error {
+	// empty documents decode to null
+	if len(bytes.TrimSpace(data)) == 0 || string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if !strings.HasSuffix(m.Kind, "List") {
+		return visit(m.Kind, data)
+	}
+	for _, item := range m.Items {
+		if err := visitManifest(item, visit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadSubjectPermissions returns the SubjectPermissions and ClusterSubjectPermissions of YAML or JSON manifests,
+// other objects are ignored
+func ReadSubjectPermissions(r io.Reader) ([]managedv1alpha1.SubjectPermissionObject, error) {
+	var subjectPermissions []managedv1alpha1.SubjectPermissionObject
+	err := decodeManifests(r, func(kind string, data []byte) error {
+		var sp managedv1alpha1.SubjectPermissionObject
+		switch kind {
+		case "SubjectPermission":
+			sp = &managedv1alpha1.SubjectPermission{}
+		case "ClusterSubjectPermission":
+			sp = &managedv1alpha1.ClusterSubjectPermission{}
+		default:
+			return nil
+		}
+		if err := json.Unmarshal(data, sp); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", kind, err)
+		}
+		subjectPermissions = append(subjectPermissions, sp)
+		return nil
+	})
+	return subjectPermissions, err
+}
+
+// ReadNamespaces returns the Namespaces of the input, which is either YAML or JSON manifests,
+// such as the output of `oc get namespaces -o yaml`, or plain text with one namespace name per line.
+// Only manifests carry the labels of the Namespaces, plain names never match a namespaceSelector
+func ReadNamespaces(r io.Reader) (*corev1.NamespaceList, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	nsList := &corev1.NamespaceList{}
+	err = decodeManifests(bytes.NewReader(data), func(kind string, data []byte) error {
+		if kind != "Namespace" {
+			return fmt.Errorf("unexpected %q object in the namespace list", kind)
+		}
+		ns := corev1.Namespace{}
+		if err := json.Unmarshal(data, &ns); err != nil {
+			return fmt.Errorf("failed to decode Namespace: %w", err)
+		}
+		nsList.Items = append(nsList.Items, ns)
+		return nil
+	})
+	if err == nil && len(nsList.Items) != 0 {
+		return nsList, nil
+	}
+
+	// not manifests, read one namespace name per line
+	nsList = &corev1.NamespaceList{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" || strings.HasPrefix(name, "#") {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(name); len(errs) != 0 {
+			return nil, fmt.Errorf("invalid namespace name %q: %s", name, strings.Join(errs, ", "))
+		}
+		nsList.Items = append(nsList.Items, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	return nsList, scanner.Err()
+}
+
+// ReadGrantPolicy returns the grant policy of the operator ConfigMap in YAML or JSON manifests,
+// such as the output of `oc get configmap rbac-permissions-operator -o yaml`
+func ReadGrantPolicy(r io.Reader) (*controllerutil.GrantPolicy, error) {
+	var configMap *corev1.ConfigMap
+	err := decodeManifests(r, func(kind string, data []byte) error {
+		if kind != "ConfigMap" {
+			return nil
+		}
+		if configMap != nil {
+			return fmt.Errorf("expected a single ConfigMap")
+		}
+		configMap = &corev1.ConfigMap{}
+		if err := json.Unmarshal(data, configMap); err != nil {
+			return fmt.Errorf("failed to decode ConfigMap: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if configMap == nil {
+		return nil, fmt.Errorf("no ConfigMap found")
+	}
+	return controllerutil.ParseGrantPolicy(configMap.Data)
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSubjectPermissions = `
+---
+apiVersion: managed.openshift.io/v1alpha1
+kind: SubjectPermission
+metadata:
+  name: dedicated-admins
+  namespace: openshift-rbac-permissions
+spec:
+  subjectKind: Group
+  subjectName: dedicated-admins
+  clusterPermissions:
+    - dedicated-admins-cluster
+  permissions:
+    - clusterRoleName: admin
+      namespacesAllowedRegex: ".*"
+      namespacesDeniedRegex: "^(openshift-.*|kube-.*|default)$"
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+---
+apiVersion: managed.openshift.io/v1alpha1
+kind: ClusterSubjectPermission
+metadata:
+  name: dedicated-admins
+spec:
+  subjectKind: Group
+  subjectName: dedicated-admins
+  clusterPermissions:
+    - dedicated-admins-cluster
+`
+
+const testNamespaces = `
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: v1
+    kind: Namespace
+    metadata:
+      name: default
+  - apiVersion: v1
+    kind: Namespace
+    metadata:
+      name: openshift-monitoring
+  - apiVersion: v1
+    kind: Namespace
+    metadata:
+      name: team-a
+      labels:
+        team: a
+  - apiVersion: v1
+    kind: Namespace
+    metadata:
+      name: team-b
+    status:
+      phase: Terminating
+`
+
+func TestReadNamespaces(t *testing.T) {
+	nsList, err := ReadNamespaces(strings.NewReader(testNamespaces))
+	require.NoError(t, err)
+	require.Len(t, nsList.Items, 4)
+	assert.Equal(t, map[string]string{"team": "a"}, nsList.Items[2].Labels)
+
+	nsList, err = ReadNamespaces(strings.NewReader("# exported namespaces\ndefault\n\nteam-a\n"))
+	require.NoError(t, err)
+	require.Len(t, nsList.Items, 2)
+	assert.Equal(t, "team-a", nsList.Items[1].Name)
+
+	_, err = ReadNamespaces(strings.NewReader("Not_A_Namespace\n"))
+	assert.Error(t, err)
+}
+
+func TestPlanSubjectPermission(t *testing.T) {
+	subjectPermissions, err := ReadSubjectPermissions(strings.NewReader(testSubjectPermissions))
+	require.NoError(t, err)
+	require.Len(t, subjectPermissions, 2)
+	nsList, err := ReadNamespaces(strings.NewReader(testNamespaces))
+	require.NoError(t, err)
+
+	plan := PlanSubjectPermission(subjectPermissions[0], nsList, nil)
+	assert.Empty(t, plan.Errors)
+	assert.Equal(t, "openshift-rbac-permissions/dedicated-admins", plan.SubjectPermission)
+	// the denied and terminating namespaces are left out
+	require.Len(t, plan.Bindings, 2)
+	assert.Equal(t, "ClusterRoleBinding", plan.Bindings[0].Kind)
+	assert.Equal(t, "dedicated-admins-cluster", plan.Bindings[0].ClusterRole)
+	assert.Equal(t, "Group/dedicated-admins", plan.Bindings[0].Subject)
+	assert.Equal(t, "RoleBinding", plan.Bindings[1].Kind)
+	assert.Equal(t, "team-a", plan.Bindings[1].Namespace)
+	assert.Equal(t, "admin", plan.Bindings[1].ClusterRole)
+
+	plan = PlanSubjectPermission(subjectPermissions[1], nsList, nil)
+	assert.Empty(t, plan.Errors)
+	assert.Equal(t, "/dedicated-admins", plan.SubjectPermission)
+	require.Len(t, plan.Bindings, 1)
+	assert.Equal(t, "ClusterRoleBinding", plan.Bindings[0].Kind)
+}
+
+func TestPlanWithGrantPolicy(t *testing.T) {
+	policy, err := ReadGrantPolicy(strings.NewReader(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: rbac-permissions-operator
+  namespace: openshift-rbac-permissions
+data:
+  clusterScopeDeniedClusterRoles: dedicated-admins-cluster
+  deniedNamespaceSelectors: team=a
+`))
+	require.NoError(t, err)
+	subjectPermissions, err := ReadSubjectPermissions(strings.NewReader(strings.Replace(testSubjectPermissions, `"^(openshift-.*|kube-.*|default)$"`, `"^openshift-.*"`, 1)))
+	require.NoError(t, err)
+	nsList, err := ReadNamespaces(strings.NewReader(testNamespaces))
+	require.NoError(t, err)
+
+	// the denied ClusterRole and the namespaces protected by the policy are left out
+	plan := PlanSubjectPermission(subjectPermissions[0], nsList, policy)
+	assert.Empty(t, plan.Errors)
+	require.Len(t, plan.Bindings, 1)
+	assert.Equal(t, "RoleBinding", plan.Bindings[0].Kind)
+	assert.Equal(t, "default", plan.Bindings[0].Namespace)
+
+	_, err = ReadGrantPolicy(strings.NewReader(testNamespaces))
+	assert.Error(t, err)
+}
+
+func TestPlanInvalidSubjectPermission(t *testing.T) {
+	subjectPermissions, err := ReadSubjectPermissions(strings.NewReader(strings.Replace(testSubjectPermissions, `"^(openshift-.*|kube-.*|default)$"`, `"(openshift-"`, 1)))
+	require.NoError(t, err)
+
+	plan := PlanSubjectPermission(subjectPermissions[0], nil, nil)
+	require.Len(t, plan.Errors, 1)
+	assert.Contains(t, plan.Errors[0], "spec.permissions[0].namespacesDeniedRegex")
+	assert.Empty(t, plan.Bindings)
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "namespaces.txt"), []byte("default\nteam-a\n"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "manifests"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifests", "subjectpermission.yaml"), []byte(testSubjectPermissions), 0o600))
+
+	var out bytes.Buffer
+	ok, err := run(filepath.Join(dir, "namespaces.txt"), "", []string{filepath.Join(dir, "manifests")}, "table", &out)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Contains(t, lines[0], "SUBJECTPERMISSION")
+	assert.Contains(t, lines[2], "team-a")
+	assert.NotContains(t, out.String(), " default ")
+
+	out.Reset()
+	ok, err = run(filepath.Join(dir, "namespaces.txt"), "", []string{filepath.Join(dir, "manifests")}, "json", &out)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Contains(t, out.String(), `"namespace": "team-a"`)
+}
@@ -18,4 +18,8 @@ const (
 	OperatorConfigMapName string = "rbac-permissions-operator"
 	OperatorName          string = "rbac-permissions-operator"
 	OperatorNamespace     string = "openshift-rbac-permissions"
+	OperatorLockName      string = "rbac-permissions-operator-lock"
+	OperatorMetricsPort   string = "8181"
+	// SubjectPermissionFinalizer holds SubjectPermissions until their bindings are deleted
+	SubjectPermissionFinalizer string = "subjectpermission.managed.openshift.io/finalizer"
 )
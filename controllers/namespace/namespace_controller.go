@@ -18,16 +18,31 @@ package namespace
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	controllerutil "github.com/openshift/rbac-permissions-operator/pkg/controllerutils"
+	localmetrics "github.com/openshift/rbac-permissions-operator/pkg/metrics"
+	"github.com/openshift/rbac-permissions-operator/pkg/operatorconfig"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
 )
@@ -38,6 +53,21 @@ var log = logf.Log.WithName("controller_namespace")
 type NamespaceReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Config is the operator configuration, reloaded when the operator ConfigMap changes.
+	// The defaults are used without it
+	Config *operatorconfig.Store
+
+	// IgnoreClusterSubjectPermissions leaves the RoleBindings of ClusterSubjectPermissions to another instance of the
+	// operator, it is set when only some namespaces are watched for SubjectPermissions
+	IgnoreClusterSubjectPermissions bool
+
+	// Recorder emits the Events of the grants, revokes and failures on the SubjectPermissions and the Namespace,
+	// no Event is emitted without it
+	Recorder events.EventRecorder
+
+	// matchers caches the compiled Permissions of every SubjectPermission
+	matchers controllerutil.MatcherCache
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -67,12 +97,8 @@ func (r *NamespaceReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 		return ctrl.Result{}, fmt.Errorf("failed to get Namespace %s: %w", request.NamespacedName, err)
 	}
 
-	namespaceList := &corev1.NamespaceList{}
-	err = r.List(ctx, namespaceList)
-	if err != nil {
-		reqLogger.Error(err, "Failed to get namespaceList")
-		return ctrl.Result{}, fmt.Errorf("failed to list Namespaces: %w", err)
-	}
+	// RoleBindings withheld by the grant policy are revoked, the SubjectPermission controller reports them
+	policy := r.Config.Policy()
 
 	subjectPermissionList := &managedv1alpha1.SubjectPermissionList{}
 	err = r.List(ctx, subjectPermissionList)
@@ -80,62 +106,214 @@ func (r *NamespaceReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 		reqLogger.Error(err, "Failed to get subjectPermissionList")
 		return ctrl.Result{}, fmt.Errorf("failed to list SubjectPermissions: %w", err)
 	}
+	clusterSubjectPermissionList := &managedv1alpha1.ClusterSubjectPermissionList{}
+	if !r.IgnoreClusterSubjectPermissions {
+		err = r.List(ctx, clusterSubjectPermissionList)
+		if err != nil {
+			reqLogger.Error(err, "Failed to get clusterSubjectPermissionList")
+			return ctrl.Result{}, fmt.Errorf("failed to list ClusterSubjectPermissions: %w", err)
+		}
+	}
 
-	roleBindingList := &v1.RoleBindingList{}
-	// request.Name is the instance namespace we are reconciling
-	opts := []client.ListOption{
-		client.InNamespace(request.Name),
+	// the permissions of both kinds are applied the same way
+	subjectPermissions := make([]managedv1alpha1.SubjectPermissionObject, 0, len(subjectPermissionList.Items)+len(clusterSubjectPermissionList.Items))
+	for i := range subjectPermissionList.Items {
+		subjectPermissions = append(subjectPermissions, &subjectPermissionList.Items[i])
 	}
-	err = r.List(ctx, roleBindingList, opts...)
-	if err != nil {
-		reqLogger.Error(err, "Failed to get rolebindingList")
-		return ctrl.Result{}, fmt.Errorf("failed to list RoleBindings in namespace %s: %w", request.Name, err)
+	for i := range clusterSubjectPermissionList.Items {
+		subjectPermissions = append(subjectPermissions, &clusterSubjectPermissionList.Items[i])
 	}
+	r.matchers.Retain(subjectPermissions)
 
 	// loop through all subject permissions
-	// get namespaces allowed in each permission
-	// if our namespace instance is in the safeList, create rolebinding and update condition
-	for _, subjectPermission := range subjectPermissionList.Items {
-		subPerm := subjectPermission
-		var successfulClusterRoleNames []string
-		for _, permission := range subPerm.Spec.Permissions {
-			successfulClusterRoleNames = append(successfulClusterRoleNames, permission.ClusterRoleName)
-
-			// list of all namespaces in safelist
-			safeList := controllerutil.GenerateSafeList(permission.NamespacesAllowedRegex, permission.NamespacesDeniedRegex, namespaceList)
-			// if namespace is in safeList, create RoleBinding
-			if NamespaceInSlice(instance.Name, safeList) && controllerutil.ValidateNamespace(instance) {
-
-				roleBinding := controllerutil.NewRoleBindingForClusterRole(permission.ClusterRoleName, subPerm.Spec.SubjectName, subPerm.Spec.SubjectNamespace, subPerm.Spec.SubjectKind, instance.Name)
-				// if rolebinding is already created in the namespace, continue to next iteration
-				if RolebindingInNamespace(roleBinding, roleBindingList) {
-					continue
-				}
+	// check if our namespace instance matches each permission,
+	// if it does create rolebinding,
+	// otherwise remove the rolebindings the subject permission no longer grants in the namespace.
+	// The conditions and the RoleBindings counts of the subject permissions are owned by the SubjectPermission
+	// controller, which reconciles the owner of every RoleBinding created or deleted here. The failing, protected and
+	// missing Role namespaces of the inventory are kept up to date for the namespace.
+	// A subject permission that fails to apply does not hold back the others, its error is returned after the loop
+	var errs []error
+	for _, subPerm := range subjectPermissions {
+		// the bindings of subject permissions in DryRun mode are only planned by the SubjectPermission controller
+		if subPerm.GetSpec().Mode == managedv1alpha1.ModeDryRun {
+			continue
+		}
+		// the bindings of expired subject permissions are revoked by the SubjectPermission controller
+		if controllerutil.IsExpired(subPerm, time.Now()) {
+			continue
+		}
+		// the bindings of subject permissions being deleted are removed by the finalizer of the SubjectPermission
+		// controller, recreating them would leave them behind once the finalizer is removed
+		if subPerm.GetDeletionTimestamp() != nil {
+			continue
+		}
 
-				err := r.Create(ctx, roleBinding)
+		// get the RoleBindings created for the subject permission in the namespace from the index on their owner
+		// request.Name is the instance namespace we are reconciling
+		roleBindingList := &v1.RoleBindingList{}
+		opts := []client.ListOption{
+			client.InNamespace(request.Name),
+			client.MatchingFields{controllerutil.SubjectPermissionOwnerField: controllerutil.OwnerIndexValue(subPerm)},
+		}
+		err = r.List(ctx, roleBindingList, opts...)
+		if err != nil {
+			reqLogger.Error(err, "Failed to get rolebindingList", "subjectPermission", subPerm.GetName())
+			return ctrl.Result{}, fmt.Errorf("failed to list RoleBindings in namespace %s: %w", request.Name, err)
+		}
+
+		originalStatus := subPerm.GetStatus().DeepCopy()
+		desiredRoleBindings := map[string]bool{}
+		skipRevoke := false
+		var applyErr error
+		// the matchers are compiled once per generation of the subject permission
+		matchers, matcherErrs := r.matchers.MatchersFor(subPerm)
+		// a role may be granted by several Permissions, the namespace is protected from it if one matches
+		protectedFrom := map[managedv1alpha1.PermissionRoleRef]bool{}
+		// the Roles granted in the namespace that it does not hold
+		missingRoles := map[managedv1alpha1.PermissionRoleRef]bool{}
+		for j, permission := range subPerm.GetSpec().Permissions {
+			role := permission.Role()
+			if matcherErrs[j] != nil {
+				// the SubjectPermission controller reports invalid permissions, keep going for the others
+				reqLogger.Error(matcherErrs[j], "Failed to match namespaces", "subjectPermission", subPerm.GetName(), "role", role.String())
+				skipRevoke = true
+				continue
+			}
+			failed := false
+			// if namespace matches the permission and the policy allows it, create RoleBinding.
+			// The policy limits the ClusterRoles, the Roles belong to the namespace
+			granted := (role.IsRole() || policy.PermitsNamespacedClusterRoleOf(subPerm.GetSpec(), role.Name)) && matchers[j].Matches(instance) && controllerutil.ValidateNamespace(instance)
+			// the protected namespaces are excluded after the rules of the permission
+			protected := granted && !policy.PermitsNamespace(instance)
+			protectedFrom[role] = protectedFrom[role] || protected
+			roleMissing := false
+			if granted && !protected {
+				exists, err := r.roleExists(ctx, role, instance.Name)
 				if err != nil {
-					if k8serr.IsAlreadyExists(err) {
+					reqLogger.Error(err, "Failed to get the role of the Permission", "role", role.String(), "namespace", instance.Name)
+					return ctrl.Result{}, err
+				}
+				roleMissing = !exists
+			}
+			// missing ClusterRoles are reported by the SubjectPermission controller
+			if role.IsRole() {
+				missingRoles[role] = missingRoles[role] || roleMissing
+			}
+			if granted && !protected {
+
+				for _, subject := range controllerutil.SubjectsOf(subPerm.GetSpec()) {
+					roleBinding := controllerutil.NewRoleBinding(subPerm, role, subject.Name, subject.Namespace, subject.Kind, instance.Name)
+					controllerutil.SetOwnershipMetadata(roleBinding, subPerm, role.String())
+					desiredRoleBindings[roleBinding.Name] = true
+					// the RoleBindings are created once their role exists, existing ones are kept meanwhile
+					if roleMissing {
+						continue
+					}
+					// create the rolebinding, or restore it if it was changed since
+					existing := controllerutil.FindRoleBinding(instance.Name, roleBinding.Name, roleBindingList)
+					op, err := controllerutil.EnsureRoleBinding(ctx, r.Client, roleBinding, existing)
+					if err != nil {
+						reqLogger.Error(err, "Failed to create RoleBinding", "name", roleBinding.Name, "namespace", instance.Name)
+						controllerutil.RecordNamespaceFailure(controllerutil.PermissionStatusFor(&subPerm.GetStatus().Permissions, role), instance.Name, err.Error())
+						controllerutil.RecordEvent(r.Recorder, subPerm, instance, corev1.EventTypeWarning, controllerutil.EventReasonBindingFailed, controllerutil.EventActionGrant, "Failed to apply RoleBinding %s in namespace %s: %v", roleBinding.Name, instance.Name, err)
+						failed = true
+						applyErr = fmt.Errorf("failed to create RoleBinding %s in namespace %s: %w", roleBinding.Name, instance.Name, err)
 						continue
 					}
-					reqLogger.Error(err, "Failed to create RoleBinding", "name", roleBinding.Name, "namespace", instance.Name)
-					return ctrl.Result{}, fmt.Errorf("failed to create RoleBinding %s in namespace %s: %w", roleBinding.Name, instance.Name, err)
+					switch op {
+					case ctrlutil.OperationResultCreated:
+						reqLogger.Info("RoleBinding created successfully", "name", roleBinding.Name, "namespace", instance.Name, "subject", subject.Name)
+						controllerutil.RecordEvent(r.Recorder, subPerm, instance, corev1.EventTypeNormal, controllerutil.EventReasonBindingCreated, controllerutil.EventActionGrant, "Created RoleBinding %s in namespace %s granting %s %s to %s", roleBinding.Name, instance.Name, role.Kind, role.Name, controllerutil.SubjectKey(subject))
+					case ctrlutil.OperationResultUpdated:
+						reqLogger.Info("RoleBinding restored to desired state", "name", roleBinding.Name, "namespace", instance.Name, "subject", subject.Name)
+						controllerutil.RecordEvent(r.Recorder, subPerm, instance, corev1.EventTypeNormal, controllerutil.EventReasonBindingRestored, controllerutil.EventActionGrant, "Restored RoleBinding %s in namespace %s to its desired state", roleBinding.Name, instance.Name)
+					}
+				}
+			}
+			if permissionStatus := controllerutil.FindPermissionStatus(subPerm.GetStatus().Permissions, role); permissionStatus != nil && !failed {
+				controllerutil.ClearNamespaceFailure(permissionStatus, instance.Name)
+			}
+		}
+		for role, protected := range protectedFrom {
+			if protected {
+				controllerutil.AddProtectedNamespace(controllerutil.PermissionStatusFor(&subPerm.GetStatus().Permissions, role), instance.Name)
+				if previous := controllerutil.FindPermissionStatus(originalStatus.Permissions, role); previous == nil || !slices.Contains(previous.ProtectedNamespaces, instance.Name) {
+					controllerutil.RecordEvent(r.Recorder, subPerm, instance, corev1.EventTypeNormal, controllerutil.EventReasonNamespaceExcluded, controllerutil.EventActionGrant, "Namespace %s is protected by the operator policy, %s %s is not granted in it", instance.Name, role.Kind, role.Name)
 				}
-				roleBindingName := fmt.Sprintf("%s-%s", permission.ClusterRoleName, subjectPermission.Spec.SubjectName)
-				reqLogger.Info("RoleBinding created successfully", "name", roleBindingName, "namespace", instance.Name, "subject", subjectPermission.Spec.SubjectName)
+			} else if permissionStatus := controllerutil.FindPermissionStatus(subPerm.GetStatus().Permissions, role); permissionStatus != nil {
+				controllerutil.RemoveProtectedNamespace(permissionStatus, instance.Name)
 			}
 		}
-		subPerm.Status.Conditions = controllerutil.UpdateCondition(subPerm.Status.Conditions, "Successfully created all roleBindings", successfulClusterRoleNames, true, managedv1alpha1.SubjectPermissionStateCreated, managedv1alpha1.RoleBindingCreated)
-		err = r.Client.Status().Update(ctx, &subPerm)
-		if err != nil {
-			reqLogger.Error(err, "Failed to update condition in namespace controller when successfully created all cluster role bindings")
-			return ctrl.Result{}, fmt.Errorf("failed to update SubjectPermission status after creating RoleBindings: %w", err)
+		for role, missing := range missingRoles {
+			if missing {
+				controllerutil.AddMissingRoleNamespace(controllerutil.PermissionStatusFor(&subPerm.GetStatus().Permissions, role), instance.Name)
+				if previous := controllerutil.FindPermissionStatus(originalStatus.Permissions, role); previous == nil || !slices.Contains(previous.MissingRoleNamespaces, instance.Name) {
+					controllerutil.RecordEvent(r.Recorder, subPerm, instance, corev1.EventTypeWarning, controllerutil.EventReasonRoleMissing, controllerutil.EventActionGrant, "Role %s for Permission does not exist in namespace %s", role.Name, instance.Name)
+				}
+			} else if permissionStatus := controllerutil.FindPermissionStatus(subPerm.GetStatus().Permissions, role); permissionStatus != nil {
+				controllerutil.RemoveMissingRoleNamespace(permissionStatus, instance.Name)
+			}
+		}
+		// without knowing every desired RoleBinding, nothing can be revoked safely
+		if !skipRevoke {
+			if err := r.revokeRoleBindings(ctx, subPerm, instance, roleBindingList, desiredRoleBindings); err != nil {
+				reqLogger.Error(err, "Failed to revoke RoleBindings", "subjectPermission", subPerm.GetName())
+				controllerutil.RecordEvent(r.Recorder, subPerm, instance, corev1.EventTypeWarning, controllerutil.EventReasonBindingFailed, controllerutil.EventActionRevoke, "Failed to revoke RoleBindings in namespace %s: %v", instance.Name, err)
+				errs = append(errs, fmt.Errorf("failed to revoke RoleBindings in namespace %s: %w", instance.Name, err))
+			}
+		}
+		if !equality.Semantic.DeepEqual(originalStatus, subPerm.GetStatus()) {
+			if err := r.Client.Status().Update(ctx, subPerm); err != nil {
+				reqLogger.Error(err, "Failed to update RoleBinding inventory in namespace controller", "subjectPermission", subPerm.GetName())
+				errs = append(errs, fmt.Errorf("failed to update the status of %s after applying RoleBindings: %w", controllerutil.OwnerIndexValue(subPerm), err))
+			}
+		}
+		if applyErr != nil {
+			errs = append(errs, applyErr)
 		}
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{}, errors.Join(errs...)
 
 }
 
+// revokeRoleBindings deletes the RoleBindings in the namespace created for the SubjectPermission that are not
+// part of the desired set, for example because the namespace labels no longer match a namespaceSelector
+func (r *NamespaceReconciler) revokeRoleBindings(ctx context.Context, subjectPermission managedv1alpha1.SubjectPermissionObject, namespace *corev1.Namespace, roleBindingList *v1.RoleBindingList, desired map[string]bool) error {
+	for i := range roleBindingList.Items {
+		rb := &roleBindingList.Items[i]
+		if desired[rb.Name] || !controllerutil.IsOwnedBy(rb, subjectPermission) {
+			continue
+		}
+		if err := r.Delete(ctx, rb); err != nil && !k8serr.IsNotFound(err) {
+			return fmt.Errorf("failed to delete RoleBinding %s: %w", rb.Name, err)
+		}
+		log.Info("RoleBinding deleted successfully", "name", rb.Name, "namespace", rb.Namespace, "subjectPermission", subjectPermission.GetName())
+		localmetrics.IncResourcesDeleted("RoleBinding", subjectPermission.GetName())
+		controllerutil.RecordEvent(r.Recorder, subjectPermission, namespace, corev1.EventTypeNormal, controllerutil.EventReasonBindingRemoved, controllerutil.EventActionRevoke, "Deleted RoleBinding %s in namespace %s of ClusterRole %s", rb.Name, rb.Namespace, rb.RoleRef.Name)
+	}
+	return nil
+}
+
+// roleExists checks if the ClusterRole of a Permission exists, or if the namespace holds its Role, the same way the
+// SubjectPermission controller holds back the bindings of missing roles
+func (r *NamespaceReconciler) roleExists(ctx context.Context, role managedv1alpha1.PermissionRoleRef, namespace string) (bool, error) {
+	var err error
+	if role.IsRole() {
+		err = r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: role.Name}, &v1.Role{})
+	} else {
+		err = r.Get(ctx, types.NamespacedName{Name: role.Name}, &v1.ClusterRole{})
+	}
+	if k8serr.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get %s %s in namespace %s: %w", role.Kind, role.Name, namespace, err)
+	}
+	return true, nil
+}
+
 // check if namespace is in safeList
 func NamespaceInSlice(namespace string, safeList []string) bool {
 	for _, ns := range safeList {
@@ -159,9 +337,35 @@ func RolebindingInNamespace(rolebinding *v1.RoleBinding, roleBindingList *v1.Rol
 	return false
 }
 
+// NamespaceForRoleBinding maps a RoleBinding, or a Role, to the Namespace it lives in
+func NamespaceForRoleBinding(ctx context.Context, obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.GetNamespace()}}}
+}
+
 // SetupWithManager sets up the controller with the Manager.
+// RoleBindings created by the operator are watched as well, so edits and deletions are reverted.
+// The RoleBindings are indexed on their owning SubjectPermission, so a namespace only reads the RoleBindings it needs.
 func (r *NamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1.RoleBinding{}, controllerutil.SubjectPermissionOwnerField, controllerutil.IndexByOwner)
+	if err != nil {
+		return fmt.Errorf("failed to index RoleBindings by SubjectPermission: %w", err)
+	}
+
+	managedRoleBindings := builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return controllerutil.IsManagedByOperator(obj)
+	}))
 
-	return ctrl.NewControllerManagedBy(mgr).For(&corev1.Namespace{}).Complete(r)
+	// the RoleBindings of a Role are created once the namespace holds it
+	roleExistence := builder.WithPredicates(predicate.Funcs{
+		UpdateFunc:  func(event.UpdateEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	})
 
+	return ctrl.NewControllerManagedBy(mgr).
+		// re-evaluate a namespace when it is created and whenever its labels change
+		For(&corev1.Namespace{}, builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.Config.Get().MaxConcurrentReconciles}).
+		Watches(&v1.RoleBinding{}, handler.EnqueueRequestsFromMapFunc(NamespaceForRoleBinding), managedRoleBindings).
+		Watches(&v1.Role{}, handler.EnqueueRequestsFromMapFunc(NamespaceForRoleBinding), roleExistence).
+		Complete(r)
 }
@@ -6,16 +6,25 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	"github.com/openshift/rbac-permissions-operator/config"
 	"github.com/openshift/rbac-permissions-operator/controllers/namespace"
 	testconst "github.com/openshift/rbac-permissions-operator/pkg/const/test"
+	controllerutil "github.com/openshift/rbac-permissions-operator/pkg/controllerutils"
+	localmetrics "github.com/openshift/rbac-permissions-operator/pkg/metrics"
+	"github.com/openshift/rbac-permissions-operator/pkg/operatorconfig"
 	clientmocks "github.com/openshift/rbac-permissions-operator/pkg/util/test/generated/mocks/client"
 )
 
@@ -26,7 +35,6 @@ var _ = Describe("Namespace Controller", func() {
 		mockStatusWriter          *clientmocks.MockStatusWriter
 		namespaceReconciler       namespace.NamespaceReconciler
 		testNamespace             *corev1.Namespace
-		testNamespaceList         *corev1.NamespaceList
 		testSubjectPermissionList v1alpha1.SubjectPermissionList
 		testRoleBinding           *rbacv1.RoleBinding
 		testRoleBindingList       *rbacv1.RoleBindingList
@@ -51,7 +59,6 @@ var _ = Describe("Namespace Controller", func() {
 			Spec:   corev1.NamespaceSpec{},
 			Status: corev1.NamespaceStatus{},
 		}
-		testNamespaceList = testconst.TestNamespaceList
 		testRoleBinding = testconst.TestRoleBinding
 		testRoleBindingList = testconst.TestRoleBindingList
 		ns = testconst.TestNamespaceName.Name
@@ -61,23 +68,34 @@ var _ = Describe("Namespace Controller", func() {
 	Context("Reconciling Namespace", func() {
 
 		When("Namespace is not in the safe list", func() {
-			It("Updates the status condition", func() {
+			BeforeEach(func() {
+				subPerm := *testconst.TestSubjectPermission.DeepCopy()
+				subPerm.Status.Permissions = []v1alpha1.PermissionStatus{
+					{
+						ClusterRoleName:     "exampleClusterRoleName",
+						RoleBindings:        2,
+						FailedNamespaces:    []v1alpha1.NamespaceFailure{{Namespace: testNamespace.Name, Reason: "fake error"}},
+						ProtectedNamespaces: []string{testNamespace.Name},
+					},
+				}
+				testSubjectPermissionList = v1alpha1.SubjectPermissionList{Items: []v1alpha1.SubjectPermission{subPerm}}
+			})
+			It("Removes the namespace from the inventory and leaves the RoleBindings count to the SubjectPermission controller", func() {
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, *testNamespace),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
 						client.InNamespace(testNamespace.Name),
+						client.MatchingFields{controllerutil.SubjectPermissionOwnerField: controllerutil.OwnerIndexValue(&testSubjectPermissionList.Items[0])},
 					}).Times(1).SetArg(1, *testconst.TestRoleBindingList),
 					mockClient.EXPECT().Status().Return(mockStatusWriter),
 					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
-						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
-							Expect(sp.Status.Conditions[1].Message).To(Equal("Successfully created all roleBindings"))
-							Expect(sp.Status.Conditions[1].ClusterRoleNames).To(ContainElement(ContainSubstring("exampleClusterRoleName")))
-							Expect(sp.Status.Conditions[1].ClusterRoleNames).To(ContainElement(ContainSubstring("testClusterRoleName")))
-							Expect(sp.Status.Conditions[1].Status).To(Equal(true))
-							Expect(sp.Status.Conditions[1].State).To(Equal(v1alpha1.SubjectPermissionStateCreated))
-							Expect(sp.Status.Conditions[1].Type).To(Equal(v1alpha1.RoleBindingCreated))
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							Expect(sp.Status.Permissions).To(HaveLen(1))
+							Expect(sp.Status.Permissions[0].RoleBindings).To(Equal(2))
+							Expect(sp.Status.Permissions[0].FailedNamespaces).To(BeEmpty())
+							Expect(sp.Status.Permissions[0].ProtectedNamespaces).To(BeEmpty())
 							return nil
 						}),
 				)
@@ -86,15 +104,101 @@ var _ = Describe("Namespace Controller", func() {
 			})
 		})
 
-		When("Namespace is in the safe list", func() {
+		When("Namespace labels no longer match the namespaceSelector", func() {
+			var staleRoleBindingList rbacv1.RoleBindingList
+
 			BeforeEach(func() {
-				testNamespaceList = &corev1.NamespaceList{
-					Items: []corev1.Namespace{
-						{
-							ObjectMeta: testNamespace.ObjectMeta,
-						},
+				subPerm := testconst.TestSubjectPermission
+				subPerm.Spec.Permissions = []v1alpha1.Permission{
+					{
+						ClusterRoleName:   "exampleClusterRoleName",
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "foo"}},
+					},
+				}
+				subPerm.Status.Permissions = []v1alpha1.PermissionStatus{{ClusterRoleName: "exampleClusterRoleName", RoleBindings: 1}}
+				testSubjectPermissionList = v1alpha1.SubjectPermissionList{Items: []v1alpha1.SubjectPermission{subPerm}}
+
+				staleRoleBinding := controllerutil.NewRoleBindingForClusterRole(&subPerm, "exampleClusterRoleName", subPerm.Spec.SubjectName, "", subPerm.Spec.SubjectKind, testNamespace.Name)
+				controllerutil.SetOwnershipMetadata(staleRoleBinding, &subPerm, "exampleClusterRoleName")
+				staleRoleBindingList = rbacv1.RoleBindingList{Items: []rbacv1.RoleBinding{*staleRoleBinding, *testconst.TestRoleBinding}}
+			})
+			It("Deletes the RoleBinding the SubjectPermission no longer grants", func() {
+				deleted := localmetrics.ResourcesDeleted.WithLabelValues("RoleBinding", testSubjectPermissionList.Items[0].Name)
+				deletedBefore := counterValue(deleted)
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, *testNamespace),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(testNamespace.Name),
+						client.MatchingFields{controllerutil.SubjectPermissionOwnerField: controllerutil.OwnerIndexValue(&testSubjectPermissionList.Items[0])},
+					}).Times(1).SetArg(1, staleRoleBindingList),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, rb *rbacv1.RoleBinding, do ...client.DeleteOption) error {
+							Expect(rb.Name).To(Equal(staleRoleBindingList.Items[0].Name))
+							return nil
+						}),
+					// the SubjectPermission controller recounts the RoleBindings once it sees the deletion
+				)
+				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+				// the deletion is counted for the SubjectPermission, which may only list its subjects under subjects
+				Expect(counterValue(deleted)).To(Equal(deletedBefore + 1))
+			})
+		})
+
+		When("The grant policy protects the namespace", func() {
+			var staleRoleBindingList rbacv1.RoleBindingList
+
+			BeforeEach(func() {
+				namespaceReconciler.Config = operatorconfig.NewStore()
+				_, err := namespaceReconciler.Config.Update(&corev1.ConfigMap{
+					Data: map[string]string{controllerutil.PolicyDeniedNamespacesKey: "^" + testNamespace.Name + "$"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				subPerm := testconst.TestSubjectPermission
+				subPerm.Spec.Permissions = []v1alpha1.Permission{
+					{
+						ClusterRoleName:        "exampleClusterRoleName",
+						NamespacesAllowedRegex: ".*",
 					},
 				}
+				subPerm.Status.Permissions = []v1alpha1.PermissionStatus{{ClusterRoleName: "exampleClusterRoleName", RoleBindings: 1}}
+				testSubjectPermissionList = v1alpha1.SubjectPermissionList{Items: []v1alpha1.SubjectPermission{subPerm}}
+
+				roleBinding := controllerutil.NewRoleBindingForClusterRole(&subPerm, "exampleClusterRoleName", subPerm.Spec.SubjectName, "", subPerm.Spec.SubjectKind, testNamespace.Name)
+				controllerutil.SetOwnershipMetadata(roleBinding, &subPerm, "exampleClusterRoleName")
+				staleRoleBindingList = rbacv1.RoleBindingList{Items: []rbacv1.RoleBinding{*roleBinding}}
+			})
+			It("Deletes the RoleBinding instead of creating it", func() {
+				recorder := events.NewFakeRecorder(10)
+				namespaceReconciler.Recorder = recorder
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, *testNamespace),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(1, staleRoleBindingList),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							Expect(sp.Status.Permissions).To(ConsistOf(v1alpha1.PermissionStatus{ClusterRoleName: "exampleClusterRoleName", RoleBindings: 1, ProtectedNamespaces: []string{testNamespace.Name}}))
+							return nil
+						}),
+				)
+				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+				// each Event is emitted on the SubjectPermission and on the Namespace
+				Expect(recorder.Events).To(HaveLen(4))
+				Expect(<-recorder.Events).To(HavePrefix("Normal NamespaceExcluded Namespace %s is protected", testNamespace.Name))
+				Expect(<-recorder.Events).To(HavePrefix("Normal NamespaceExcluded"))
+				Expect(<-recorder.Events).To(HavePrefix("Normal BindingRemoved Deleted RoleBinding"))
+				Expect(<-recorder.Events).To(HavePrefix("Normal BindingRemoved"))
+			})
+		})
+
+		When("Namespace is in the safe list", func() {
+			BeforeEach(func() {
 				testSubjectPermissionList = v1alpha1.SubjectPermissionList{
 					Items: []v1alpha1.SubjectPermission{
 						{
@@ -115,13 +219,13 @@ var _ = Describe("Namespace Controller", func() {
 								},
 							},
 							Status: v1alpha1.SubjectPermissionStatus{
-								Conditions: []v1alpha1.Condition{
+								Conditions: []metav1.Condition{
 									{
+										Type:               v1alpha1.ConditionReady,
 										LastTransitionTime: metav1.Now(),
-										ClusterRoleNames:   []string{"exampleClusterRoleName"},
 										Message:            "exampleMessage",
-										Status:             true,
-										State:              "exampleState",
+										Status:             metav1.ConditionTrue,
+										Reason:             v1alpha1.ReasonReconciled,
 									},
 								},
 							},
@@ -129,18 +233,22 @@ var _ = Describe("Namespace Controller", func() {
 					},
 				}
 			})
-			It("Creates new rolebinding and updates status condition", func() {
+			It("Creates new rolebinding", func() {
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(2, *testNamespace),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
 						client.InNamespace(testNamespace.Name),
+						client.MatchingFields{controllerutil.SubjectPermissionOwnerField: controllerutil.OwnerIndexValue(&testSubjectPermissionList.Items[0])},
 					}).Times(1).SetArg(1, *testconst.TestRoleBindingList),
+					mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "testClusterRoleName"}, gomock.AssignableToTypeOf(&rbacv1.ClusterRole{})).Times(1).Return(nil),
 					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
 						func(ctx context.Context, rb *rbacv1.RoleBinding, co ...client.CreateOption) error {
-							Expect(rb.ObjectMeta.Name).To(Equal(fmt.Sprintf("%s-%s",
+							Expect(rb.ObjectMeta.Name).To(Equal(controllerutil.BindingName(&testSubjectPermissionList.Items[0],
 								testSubjectPermissionList.Items[0].Spec.Permissions[0].ClusterRoleName,
+								testSubjectPermissionList.Items[0].Spec.SubjectKind,
+								testSubjectPermissionList.Items[0].Spec.SubjectNamespace,
 								testSubjectPermissionList.Items[0].Spec.SubjectName)))
 							Expect(rb.ObjectMeta.Namespace).To(Equal(testNamespace.Name))
 							Expect(rb.Subjects[0].Kind).To(Equal(testSubjectPermissionList.Items[0].Spec.SubjectKind))
@@ -149,38 +257,212 @@ var _ = Describe("Namespace Controller", func() {
 							Expect(rb.RoleRef.Name).To(Equal(testSubjectPermissionList.Items[0].Spec.Permissions[0].ClusterRoleName))
 							return nil
 						}),
+				)
+				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("Holds back the RoleBinding until the ClusterRole exists", func() {
+				recorder := events.NewFakeRecorder(10)
+				namespaceReconciler.Recorder = recorder
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(2, *testNamespace),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testconst.TestRoleBindingList),
+					mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "testClusterRoleName"}, gomock.AssignableToTypeOf(&rbacv1.ClusterRole{})).Times(1).
+						Return(k8serr.NewNotFound(schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "clusterroles"}, "testClusterRoleName")),
+				)
+				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+				// the missing ClusterRole is reported by the SubjectPermission controller
+				Expect(recorder.Events).To(BeEmpty())
+			})
+		})
+
+		When("A Permission binds a Role of the namespace", func() {
+			BeforeEach(func() {
+				testSubjectPermissionList = v1alpha1.SubjectPermissionList{
+					Items: []v1alpha1.SubjectPermission{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "testSubjectPermission",
+								Namespace: "rbac-permissions-operator",
+							},
+							Spec: v1alpha1.SubjectPermissionSpec{
+								SubjectName: "exampleSubjectName",
+								SubjectKind: "exampleSubjectKind",
+								Permissions: []v1alpha1.Permission{
+									{
+										RoleRef:                &v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindRole, Name: "deployer"},
+										NamespacesAllowedRegex: "test",
+									},
+								},
+							},
+						},
+					},
+				}
+			})
+			It("Creates the RoleBinding of the Role", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(2, *testNamespace),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: testNamespace.Name, Name: "deployer"}, gomock.AssignableToTypeOf(&rbacv1.Role{})).Times(1).Return(nil),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, rb *rbacv1.RoleBinding, co ...client.CreateOption) error {
+							Expect(rb.Namespace).To(Equal(testNamespace.Name))
+							Expect(rb.RoleRef).To(Equal(rbacv1.RoleRef{Kind: "Role", Name: "deployer"}))
+							return nil
+						}),
+				)
+				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("Records the namespace as missing the Role until it is created", func() {
+				recorder := events.NewFakeRecorder(10)
+				namespaceReconciler.Recorder = recorder
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(2, *testNamespace),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Namespace: testNamespace.Name, Name: "deployer"}, gomock.AssignableToTypeOf(&rbacv1.Role{})).Times(1).
+						Return(k8serr.NewNotFound(schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "roles"}, "deployer")),
 					mockClient.EXPECT().Status().Return(mockStatusWriter),
 					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
 						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
-							Expect(sp.Status.Conditions[1].Message).To(Equal("Successfully created all roleBindings"))
-							Expect(sp.Status.Conditions[1].ClusterRoleNames).To(ContainElement(ContainSubstring("testClusterRoleName")))
-							Expect(sp.Status.Conditions[1].ClusterRoleNames).ToNot(ContainElement(ContainSubstring("exampleClusterRoleName")))
-							Expect(sp.Status.Conditions[1].Status).To(Equal(true))
-							Expect(sp.Status.Conditions[1].State).To(Equal(v1alpha1.SubjectPermissionStateCreated))
-							Expect(sp.Status.Conditions[1].Type).To(Equal(v1alpha1.RoleBindingCreated))
+							Expect(sp.Status.Permissions).To(ConsistOf(v1alpha1.PermissionStatus{
+								ClusterRoleName:       "deployer",
+								Kind:                  v1alpha1.RoleKindRole,
+								MissingRoleNamespaces: []string{testNamespace.Name},
+							}))
 							return nil
 						}),
 				)
 				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).ToNot(HaveOccurred())
+				Expect(recorder.Events).To(Receive(Equal(fmt.Sprintf("Warning RoleMissing Role deployer for Permission does not exist in namespace %s", testNamespace.Name))))
 			})
 		})
 
-		When("Not able to Get the namespace instance", func() {
-			It("Should report failure", func() {
+		When("Namespace is in the safe list of a ClusterSubjectPermission", func() {
+			It("Creates new rolebinding owned by the ClusterSubjectPermission", func() {
+				clusterSubjectPermission := v1alpha1.ClusterSubjectPermission{
+					ObjectMeta: metav1.ObjectMeta{Name: "testClusterSubjectPermission"},
+					Spec: v1alpha1.SubjectPermissionSpec{
+						SubjectName: "exampleSubjectName",
+						SubjectKind: "Group",
+						Permissions: []v1alpha1.Permission{
+							{
+								ClusterRoleName:        "testClusterRoleName",
+								NamespacesAllowedRegex: "test",
+							},
+						},
+					},
+				}
 				gomock.InOrder(
-					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(2, *testNamespace),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.SubjectPermissionList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{Items: []v1alpha1.ClusterSubjectPermission{clusterSubjectPermission}}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
+						client.InNamespace(testNamespace.Name),
+						client.MatchingFields{controllerutil.SubjectPermissionOwnerField: "/testClusterSubjectPermission"},
+					}).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "testClusterRoleName"}, gomock.AssignableToTypeOf(&rbacv1.ClusterRole{})).Times(1).Return(nil),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, rb *rbacv1.RoleBinding, co ...client.CreateOption) error {
+							Expect(rb.Namespace).To(Equal(testNamespace.Name))
+							Expect(rb.Annotations[controllerutil.SubjectPermissionAnnotation]).To(Equal("/testClusterSubjectPermission"))
+							Expect(rb.Labels[controllerutil.SubjectPermissionNamespaceLabel]).To(BeEmpty())
+							return nil
+						}),
 				)
 				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
-				Expect(err).Should(HaveOccurred())
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("Only some namespaces are watched for SubjectPermissions", func() {
+			BeforeEach(func() {
+				namespaceReconciler.IgnoreClusterSubjectPermissions = true
+			})
+			It("Leaves the ClusterSubjectPermissions to the instance watching every namespace", func() {
+				// no ClusterSubjectPermission is listed
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, *testNamespace),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.SubjectPermissionList{}),
+				)
+				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("A Permission has an invalid regex", func() {
+			BeforeEach(func() {
+				subPerm := testconst.TestSubjectPermission
+				subPerm.Spec.Permissions = []v1alpha1.Permission{
+					{
+						ClusterRoleName:        "exampleClusterRoleName",
+						NamespacesAllowedRegex: "[invalid-regex",
+					},
+				}
+				testSubjectPermissionList = v1alpha1.SubjectPermissionList{Items: []v1alpha1.SubjectPermission{subPerm}}
+			})
+			It("Skips the Permission without revoking any RoleBinding", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, *testNamespace),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testconst.TestRoleBindingList),
+				)
+				Expect(func() {
+					_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+					Expect(err).ToNot(HaveOccurred())
+				}).ToNot(Panic())
+			})
+		})
+
+		When("The SubjectPermission is in DryRun mode", func() {
+			BeforeEach(func() {
+				testSubjectPermissionList.Items = []v1alpha1.SubjectPermission{*testconst.TestSubjectPermission.DeepCopy()}
+				testSubjectPermissionList.Items[0].Spec.Mode = v1alpha1.ModeDryRun
+			})
+			It("Does not apply its RoleBindings", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, *testNamespace),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
+				)
+				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 
-		When("Not able to List the NamespaceList", func() {
+		When("The SubjectPermission is being deleted", func() {
+			BeforeEach(func() {
+				testSubjectPermissionList.Items = []v1alpha1.SubjectPermission{*testconst.TestSubjectPermission.DeepCopy()}
+				deletionTimestamp := metav1.Now()
+				testSubjectPermissionList.Items[0].DeletionTimestamp = &deletionTimestamp
+				testSubjectPermissionList.Items[0].Finalizers = []string{config.SubjectPermissionFinalizer}
+			})
+			It("Does not recreate the RoleBindings its finalizer removes", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, *testNamespace),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
+				)
+				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("Not able to Get the namespace instance", func() {
 			It("Should report failure", func() {
 				gomock.InOrder(
-					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *testNamespace),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
 				)
 				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).Should(HaveOccurred())
@@ -191,7 +473,6 @@ var _ = Describe("Namespace Controller", func() {
 			It("Should report failure", func() {
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *testNamespace),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *testNamespaceList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
 				)
 				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
@@ -203,8 +484,8 @@ var _ = Describe("Namespace Controller", func() {
 			It("Should report failure", func() {
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, *testNamespace),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, *testNamespaceList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
 				)
 				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
@@ -214,13 +495,6 @@ var _ = Describe("Namespace Controller", func() {
 
 		When("Not able to Create the RoleBinding", func() {
 			BeforeEach(func() {
-				testNamespaceList = &corev1.NamespaceList{
-					Items: []corev1.Namespace{
-						{
-							ObjectMeta: testNamespace.ObjectMeta,
-						},
-					},
-				}
 				testSubjectPermissionList = v1alpha1.SubjectPermissionList{
 					Items: []v1alpha1.SubjectPermission{
 						{
@@ -241,13 +515,13 @@ var _ = Describe("Namespace Controller", func() {
 								},
 							},
 							Status: v1alpha1.SubjectPermissionStatus{
-								Conditions: []v1alpha1.Condition{
+								Conditions: []metav1.Condition{
 									{
+										Type:               v1alpha1.ConditionReady,
 										LastTransitionTime: metav1.Now(),
-										ClusterRoleNames:   []string{"exampleClusterRoleName"},
 										Message:            "exampleMessage",
-										Status:             true,
-										State:              "exampleState",
+										Status:             metav1.ConditionTrue,
+										Reason:             v1alpha1.ReasonReconciled,
 									},
 								},
 							},
@@ -256,88 +530,85 @@ var _ = Describe("Namespace Controller", func() {
 				}
 			})
 			It("Should report failure", func() {
+				recorder := events.NewFakeRecorder(10)
+				namespaceReconciler.Recorder = recorder
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(2, *testNamespace),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
 						client.InNamespace(testNamespace.Name),
+						client.MatchingFields{controllerutil.SubjectPermissionOwnerField: controllerutil.OwnerIndexValue(&testSubjectPermissionList.Items[0])},
 					}).Times(1).SetArg(1, *testconst.TestRoleBindingList),
+					mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "testClusterRoleName"}, gomock.AssignableToTypeOf(&rbacv1.ClusterRole{})).Times(1).Return(nil),
 					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
+							Expect(sp.Status.Permissions).To(HaveLen(1))
+							Expect(sp.Status.Permissions[0].FailedNamespaces).To(ConsistOf(v1alpha1.NamespaceFailure{Namespace: testNamespace.Name, Reason: "fake error"}))
+							return nil
+						}),
 				)
 				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).Should(HaveOccurred())
+				Expect(recorder.Events).To(HaveLen(2))
+				Expect(<-recorder.Events).To(HaveSuffix("in namespace %s: fake error", testNamespace.Name))
+				Expect(<-recorder.Events).To(HavePrefix("Warning BindingFailed Failed to apply RoleBinding"))
 			})
-		})
 
-		When("Not able to Update status condition for successful RoleBinding creation", func() {
-			BeforeEach(func() {
-				testNamespaceList = &corev1.NamespaceList{
-					Items: []corev1.Namespace{
-						{
-							ObjectMeta: testNamespace.ObjectMeta,
-						},
-					},
-				}
-				testSubjectPermissionList = v1alpha1.SubjectPermissionList{
-					Items: []v1alpha1.SubjectPermission{
-						{
-							ObjectMeta: metav1.ObjectMeta{
-								Name:      "testSubjectPermission",
-								Namespace: "rbac-permissions-operator",
-							},
-							Spec: v1alpha1.SubjectPermissionSpec{
-								SubjectName:        "exampleSubjectName",
-								SubjectKind:        "exampleSubjectKind",
-								ClusterPermissions: []string{"exampleClusterRoleName", "exampleClusterRoleNameTwo"},
-								Permissions: []v1alpha1.Permission{
-									{
-										ClusterRoleName:        "testClusterRoleName",
-										NamespacesAllowedRegex: "test",
-										NamespacesDeniedRegex:  "",
-									},
-								},
-							},
-							Status: v1alpha1.SubjectPermissionStatus{
-								Conditions: []v1alpha1.Condition{
-									{
-										LastTransitionTime: metav1.Now(),
-										ClusterRoleNames:   []string{"exampleClusterRoleName"},
-										Message:            "exampleMessage",
-										Status:             true,
-										State:              "exampleState",
-									},
-								},
-							},
-						},
+			It("Still applies the RoleBindings of the next SubjectPermission", func() {
+				other := *testSubjectPermissionList.Items[0].DeepCopy()
+				other.Name = "otherSubjectPermission"
+				testSubjectPermissionList.Items = append(testSubjectPermissionList.Items, other)
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(2, *testNamespace),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "testClusterRoleName"}, gomock.AssignableToTypeOf(&rbacv1.ClusterRole{})).Times(1).Return(nil),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Return(fmt.Errorf("fake error")),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "testClusterRoleName"}, gomock.AssignableToTypeOf(&rbacv1.ClusterRole{})).Times(1).Return(nil),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+						func(ctx context.Context, rb *rbacv1.RoleBinding, co ...client.CreateOption) error {
+							Expect(rb.Labels).To(ContainElement(other.Name))
+							return nil
+						}),
+				)
+				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake error"))
+			})
+
+			It("Clears the failure once the RoleBinding is created", func() {
+				testSubjectPermissionList.Items[0].Status.Permissions = []v1alpha1.PermissionStatus{
+					{
+						ClusterRoleName:  "testClusterRoleName",
+						FailedNamespaces: []v1alpha1.NamespaceFailure{{Namespace: testNamespace.Name, Reason: "fake error"}},
 					},
 				}
-			})
-			It("Should report failure", func() {
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).SetArg(2, *testNamespace),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testSubjectPermissionList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{}),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
 						client.InNamespace(testNamespace.Name),
+						client.MatchingFields{controllerutil.SubjectPermissionOwnerField: controllerutil.OwnerIndexValue(&testSubjectPermissionList.Items[0])},
 					}).Times(1).SetArg(1, *testconst.TestRoleBindingList),
-					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
-						func(ctx context.Context, rb *rbacv1.RoleBinding, co ...client.CreateOption) error {
-							Expect(rb.ObjectMeta.Name).To(Equal(fmt.Sprintf("%s-%s",
-								testSubjectPermissionList.Items[0].Spec.Permissions[0].ClusterRoleName,
-								testSubjectPermissionList.Items[0].Spec.SubjectName)))
-							Expect(rb.ObjectMeta.Namespace).To(Equal(testNamespace.Name))
-							Expect(rb.Subjects[0].Kind).To(Equal(testSubjectPermissionList.Items[0].Spec.SubjectKind))
-							Expect(rb.Subjects[0].Name).To(Equal(testSubjectPermissionList.Items[0].Spec.SubjectName))
-							Expect(rb.RoleRef.Kind).To(Equal("ClusterRole"))
-							Expect(rb.RoleRef.Name).To(Equal(testSubjectPermissionList.Items[0].Spec.Permissions[0].ClusterRoleName))
+					mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "testClusterRoleName"}, gomock.AssignableToTypeOf(&rbacv1.ClusterRole{})).Times(1).Return(nil),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
+							Expect(sp.Status.Permissions).To(ConsistOf(v1alpha1.PermissionStatus{ClusterRoleName: "testClusterRoleName"}))
 							return nil
 						}),
-					mockClient.EXPECT().Status().Return(mockStatusWriter),
-					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(fmt.Errorf("fake error")),
 				)
 				_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
-				Expect(err).Should(HaveOccurred())
+				Expect(err).ToNot(HaveOccurred())
 			})
 		})
 	})
@@ -390,13 +661,21 @@ var _ = Describe("Namespace Controller", func() {
 		})
 	})
 
+	Context("Testing NamespaceForRoleBinding function", func() {
+		It("Should enqueue the namespace of the RoleBinding", func() {
+			requests := namespace.NamespaceForRoleBinding(testconst.Context, testconst.TestRoleBinding)
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Name).To(Equal(testconst.TestRoleBinding.Namespace))
+			Expect(requests[0].Namespace).To(BeEmpty())
+		})
+	})
+
 	// Additional edge case test
 	When("SubjectPermissionList fails", func() {
 		It("Should return error", func() {
 			listError := fmt.Errorf("subjectpermission list failed")
 			gomock.InOrder(
 				mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, *testNamespace),
-				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
 				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(listError),
 			)
 			_, err := namespaceReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
@@ -409,3 +688,10 @@ var _ = Describe("Namespace Controller", func() {
 		mockCtrl.Finish()
 	})
 })
+
+// counterValue returns the current value of the counter
+func counterValue(counter prometheus.Counter) float64 {
+	metric := &dto.Metric{}
+	Expect(counter.Write(metric)).To(Succeed())
+	return metric.GetCounter().GetValue()
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorconfig
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/openshift/rbac-permissions-operator/config"
+	"github.com/openshift/rbac-permissions-operator/pkg/operatorconfig"
+)
+
+var log = logf.Log.WithName("controller_operatorconfig")
+
+// ConfigMapReconciler reloads the operator configuration when the operator ConfigMap changes
+type ConfigMapReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Config is updated with the content of the ConfigMap, and notifies the controllers watching it
+	Config *operatorconfig.Store
+	// Namespace is the namespace of the operator ConfigMap
+	Namespace string
+}
+
+// Reconcile reads the operator ConfigMap into the configuration. An invalid ConfigMap keeps the configuration
+// in effect and is retried until it is fixed, a deleted ConfigMap keeps the last configuration loaded
+func (r *ConfigMapReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, r.key(), configMap); err != nil {
+		if k8serr.IsNotFound(err) {
+			reqLogger.Info("Operator ConfigMap not found, keeping the configuration in effect")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get the operator ConfigMap: %w", err)
+	}
+
+	previous := r.Config.Get()
+	changed, err := r.Config.Update(configMap)
+	if err != nil {
+		reqLogger.Error(err, "Failed to reload the operator configuration, keeping the configuration in effect")
+		return ctrl.Result{}, fmt.Errorf("failed to reload the operator configuration: %w", err)
+	}
+	if !changed {
+		return ctrl.Result{}, nil
+	}
+	if keys := operatorconfig.RestartRequired(previous, r.Config.Get()); len(keys) != 0 {
+		reqLogger.Info("Operator configuration reloaded, restart the operator to apply the changed startup keys", "keys", keys)
+	} else {
+		reqLogger.Info("Operator configuration reloaded")
+	}
+	return ctrl.Result{}, nil
+}
+
+// key returns the name of the operator ConfigMap
+func (r *ConfigMapReconciler) key() types.NamespacedName {
+	return types.NamespacedName{Namespace: r.Namespace, Name: config.OperatorConfigMapName}
+}
+
+// SetupWithManager sets up the controller with the Manager, only the operator ConfigMap is reconciled.
+func (r *ConfigMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	operatorConfigMap := builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return client.ObjectKeyFromObject(obj) == r.key()
+	}))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("operatorconfig").
+		For(&corev1.ConfigMap{}, operatorConfigMap).
+		Complete(r)
+}
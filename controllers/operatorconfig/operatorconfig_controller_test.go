@@ -0,0 +1,88 @@
+package operatorconfig_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	controllers "github.com/openshift/rbac-permissions-operator/controllers/operatorconfig"
+	testconst "github.com/openshift/rbac-permissions-operator/pkg/const/test"
+	"github.com/openshift/rbac-permissions-operator/pkg/operatorconfig"
+	clientmocks "github.com/openshift/rbac-permissions-operator/pkg/util/test/generated/mocks/client"
+)
+
+var _ = Describe("Operator Config Controller", func() {
+	var (
+		mockCtrl   *gomock.Controller
+		mockClient *clientmocks.MockClient
+		store      *operatorconfig.Store
+		reconciler controllers.ConfigMapReconciler
+		key        types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockClient = clientmocks.NewMockClient(mockCtrl)
+		store = operatorconfig.NewStore()
+		reconciler = controllers.ConfigMapReconciler{
+			Client:    mockClient,
+			Scheme:    testconst.Scheme,
+			Config:    store,
+			Namespace: "openshift-rbac-permissions",
+		}
+		key = types.NamespacedName{Namespace: "openshift-rbac-permissions", Name: "rbac-permissions-operator"}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	When("The operator ConfigMap changes", func() {
+		It("Reloads the configuration and notifies the subscribers", func() {
+			changes := store.Subscribe()
+			mockClient.EXPECT().Get(gomock.Any(), key, gomock.Any()).SetArg(2, corev1.ConfigMap{Data: map[string]string{operatorconfig.ResyncPeriodKey: "10m"}})
+			_, err := reconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: key})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(store.Get().ResyncPeriod).To(Equal(10 * time.Minute))
+			Expect(changes).To(HaveLen(1))
+		})
+	})
+
+	When("The operator ConfigMap is invalid", func() {
+		It("Keeps the configuration in effect and retries", func() {
+			mockClient.EXPECT().Get(gomock.Any(), key, gomock.Any()).SetArg(2, corev1.ConfigMap{Data: map[string]string{operatorconfig.MaxConcurrentReconcilesKey: "many"}})
+			_, err := reconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: key})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(operatorconfig.MaxConcurrentReconcilesKey))
+			Expect(store.Get()).To(Equal(operatorconfig.Default()))
+		})
+	})
+
+	When("The operator ConfigMap is deleted", func() {
+		It("Keeps the last configuration loaded", func() {
+			_, err := store.Update(&corev1.ConfigMap{Data: map[string]string{operatorconfig.ResyncPeriodKey: "10m"}})
+			Expect(err).ToNot(HaveOccurred())
+			changes := store.Subscribe()
+			mockClient.EXPECT().Get(gomock.Any(), key, gomock.Any()).Return(k8serr.NewNotFound(corev1.Resource("configmaps"), key.Name))
+			_, err = reconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: key})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(store.Get().ResyncPeriod).To(Equal(10 * time.Minute))
+			Expect(changes).To(BeEmpty())
+		})
+	})
+
+	When("The ConfigMap cannot be read", func() {
+		It("Should report failure", func() {
+			mockClient.EXPECT().Get(gomock.Any(), key, gomock.Any()).Return(fmt.Errorf("fake error"))
+			_, err := reconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: key})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,131 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subjectpermission
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/rbac/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	controllerutil "github.com/openshift/rbac-permissions-operator/pkg/controllerutils"
+)
+
+// ClusterSubjectPermissionReconciler reconciles a ClusterSubjectPermission object,
+// with the code shared with the SubjectPermissionReconciler
+type ClusterSubjectPermissionReconciler struct {
+	SubjectPermissionReconciler
+}
+
+// Reconcile applies the ClusterRoleBindings and RoleBindings of a ClusterSubjectPermission
+func (r *ClusterSubjectPermissionReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	return r.reconcileSubjectPermission(ctx, request, &managedv1alpha1.ClusterSubjectPermission{})
+}
+
+// ClusterSubjectPermissionForBinding maps a binding created by the operator to the ClusterSubjectPermission owning it
+func ClusterSubjectPermissionForBinding(ctx context.Context, obj client.Object) []reconcile.Request {
+	owner, ok := controllerutil.OwnerOf(obj)
+	if !ok || owner.Namespace != "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: owner}}
+}
+
+// allClusterSubjectPermissions maps a change of the operator configuration to every ClusterSubjectPermission
+func (r *ClusterSubjectPermissionReconciler) allClusterSubjectPermissions(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusterSubjectPermissionList := &managedv1alpha1.ClusterSubjectPermissionList{}
+	if err := r.List(ctx, clusterSubjectPermissionList); err != nil {
+		log.Error(err, "Failed to list ClusterSubjectPermissions after a configuration change")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(clusterSubjectPermissionList.Items))
+	for i := range clusterSubjectPermissionList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&clusterSubjectPermissionList.Items[i])})
+	}
+	return requests
+}
+
+// ClusterSubjectPermissionsForClusterRole maps a ClusterRole to the ClusterSubjectPermissions granting it, found with
+// the index on ClusterRoleReferenceField, and to the ClusterSubjectPermission it was created for
+func (r *ClusterSubjectPermissionReconciler) ClusterSubjectPermissionsForClusterRole(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := ClusterSubjectPermissionForBinding(ctx, obj)
+	clusterSubjectPermissionList := &managedv1alpha1.ClusterSubjectPermissionList{}
+	if err := r.List(ctx, clusterSubjectPermissionList, client.MatchingFields{controllerutil.ClusterRoleReferenceField: obj.GetName()}); err != nil {
+		log.Error(err, "Failed to list the ClusterSubjectPermissions of a ClusterRole", "clusterRoleName", obj.GetName())
+		return requests
+	}
+	for i := range clusterSubjectPermissionList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&clusterSubjectPermissionList.Items[i])})
+	}
+	return requests
+}
+
+// ClusterSubjectPermissionsForRole maps a Role to the ClusterSubjectPermissions with a Permission binding a Role of its
+// name, found with the index on RoleReferenceField
+func (r *ClusterSubjectPermissionReconciler) ClusterSubjectPermissionsForRole(ctx context.Context, obj client.Object) []reconcile.Request {
+	clusterSubjectPermissionList := &managedv1alpha1.ClusterSubjectPermissionList{}
+	if err := r.List(ctx, clusterSubjectPermissionList, client.MatchingFields{controllerutil.RoleReferenceField: obj.GetName()}); err != nil {
+		log.Error(err, "Failed to list the ClusterSubjectPermissions of a Role", "roleName", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(clusterSubjectPermissionList.Items))
+	for i := range clusterSubjectPermissionList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&clusterSubjectPermissionList.Items[i])})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// Bindings created by the operator are watched as well, so edits and deletions are reverted,
+// and every ClusterSubjectPermission is reconciled again when the operator configuration changes.
+// Like SubjectPermissions, ClusterSubjectPermissions are reconciled when a ClusterRole or Role they grant is created
+// or deleted, and when a ClusterRole created for them is edited.
+func (r *ClusterSubjectPermissionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &managedv1alpha1.ClusterSubjectPermission{}, controllerutil.ClusterRoleReferenceField, controllerutil.IndexByClusterRole)
+	if err != nil {
+		return fmt.Errorf("failed to index ClusterSubjectPermissions by ClusterRole: %w", err)
+	}
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &managedv1alpha1.ClusterSubjectPermission{}, controllerutil.RoleReferenceField, controllerutil.IndexByRole)
+	if err != nil {
+		return fmt.Errorf("failed to index ClusterSubjectPermissions by Role: %w", err)
+	}
+
+	managedBindings := builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return controllerutil.IsManagedByOperator(obj)
+	}))
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&managedv1alpha1.ClusterSubjectPermission{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.Config.Get().MaxConcurrentReconciles}).
+		Watches(&v1.ClusterRoleBinding{}, handler.EnqueueRequestsFromMapFunc(ClusterSubjectPermissionForBinding), managedBindings).
+		Watches(&v1.RoleBinding{}, handler.EnqueueRequestsFromMapFunc(ClusterSubjectPermissionForBinding), managedBindings).
+		Watches(&v1.ClusterRole{}, handler.EnqueueRequestsFromMapFunc(r.ClusterSubjectPermissionsForClusterRole), clusterRoleChanges).
+		Watches(&v1.Role{}, handler.EnqueueRequestsFromMapFunc(r.ClusterSubjectPermissionsForRole), roleExistence)
+	if r.Config != nil {
+		b = b.WatchesRawSource(source.Channel(r.Config.Subscribe(), handler.EnqueueRequestsFromMapFunc(r.allClusterSubjectPermissions)))
+	}
+	return b.Complete(r)
+}
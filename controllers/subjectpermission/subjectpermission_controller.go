@@ -20,21 +20,39 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 
+	"github.com/openshift/rbac-permissions-operator/config"
 	controllerutil "github.com/openshift/rbac-permissions-operator/pkg/controllerutils"
 	localmetrics "github.com/openshift/rbac-permissions-operator/pkg/metrics"
+	"github.com/openshift/rbac-permissions-operator/pkg/operatorconfig"
 	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/validation/path"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
 )
@@ -46,6 +64,17 @@ type SubjectPermissionReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 
+	// Config is the operator configuration, reloaded when the operator ConfigMap changes.
+	// The defaults are used without it
+	Config *operatorconfig.Store
+
+	// Recorder emits the Events of the grants, revokes and failures, no Event is emitted without it
+	Recorder events.EventRecorder
+
+	// WatchNamespaces are the namespaces of the SubjectPermissions the operator reconciles, every namespace when empty.
+	// They must match the namespaces of the manager cache
+	WatchNamespaces []string
+
 	// Test-friendly flags to disable certain features during testing
 	DisableValidation bool
 	DisableFinalizers bool
@@ -61,6 +90,12 @@ type SubjectPermissionReconciler struct {
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.2/pkg/reconcile
 func (r *SubjectPermissionReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	return r.reconcileSubjectPermission(ctx, request, &managedv1alpha1.SubjectPermission{})
+}
+
+// reconcileSubjectPermission fetches the SubjectPermission or ClusterSubjectPermission of the request into the
+// empty instance and applies its bindings. Both kinds share this code, they only differ in their scope
+func (r *SubjectPermissionReconciler) reconcileSubjectPermission(ctx context.Context, request ctrl.Request, instance managedv1alpha1.SubjectPermissionObject) (ctrl.Result, error) {
 	startTime := time.Now()
 	result := "success"
 
@@ -70,11 +105,11 @@ func (r *SubjectPermissionReconciler) Reconcile(ctx context.Context, request ctr
 		localmetrics.IncReconcileTotal("subjectpermission", result)
 	}()
 
+	kind := kindOf(instance)
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
-	reqLogger.Info("Reconciling SubjectPermission")
+	reqLogger.Info("Reconciling " + kind)
 
 	// Fetch the SubjectPermission instance
-	instance := &managedv1alpha1.SubjectPermission{}
 	err := r.Get(ctx, request.NamespacedName, instance)
 	if err != nil {
 		if k8serr.IsNotFound(err) {
@@ -86,64 +121,83 @@ func (r *SubjectPermissionReconciler) Reconcile(ctx context.Context, request ctr
 		// Error reading the object - requeue the request.
 		result = "error"
 		localmetrics.IncReconcileErrors("subjectpermission", "fetch")
-		return ctrl.Result{}, fmt.Errorf("failed to fetch SubjectPermission: %w", err)
+		return ctrl.Result{}, fmt.Errorf("failed to fetch %s: %w", kind, err)
+	}
+	originalStatus := instance.GetStatus().DeepCopy()
+
+	// Handle deletion first, so an invalid spec never blocks the removal of the finalizer
+	finalizer := config.SubjectPermissionFinalizer
+	if instance.GetDeletionTimestamp() != nil {
+		if r.DisableFinalizers {
+			// Simple cleanup for test mode
+			reqLogger.Info("Removing Prometheus metrics for "+kind, "name", instance.GetName())
+			localmetrics.DeletePrometheusMetric(instance)
+			return ctrl.Result{}, nil
+		}
+		if ctrlutil.ContainsFinalizer(instance, finalizer) {
+			// Perform cleanup
+			reqLogger.Info("Cleaning up "+kind+" resources", "name", instance.GetName())
+			if err := r.cleanupBindings(ctx, instance); err != nil {
+				result = "error"
+				localmetrics.IncReconcileErrors("subjectpermission", "cleanup")
+				return ctrl.Result{}, fmt.Errorf("failed to clean up bindings: %w", err)
+			}
+			localmetrics.DeletePrometheusMetric(instance)
+
+			// Remove finalizer to allow deletion
+			ctrlutil.RemoveFinalizer(instance, finalizer)
+			if err := r.Update(ctx, instance); err != nil {
+				result = "error"
+				localmetrics.IncReconcileErrors("subjectpermission", "cleanup")
+				return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
+			}
+		}
+		return ctrl.Result{}, nil
 	}
 
 	// Input validation (skip in test mode)
 	if !r.DisableValidation {
-		if err := r.validateSubjectPermission(instance); err != nil {
-			reqLogger.Error(err, "SubjectPermission validation failed")
+		if err := ValidateSubjectPermission(instance); err != nil {
+			reqLogger.Error(err, kind+" validation failed")
 			result = "validation_error"
 			localmetrics.IncReconcileErrors("subjectpermission", "validation")
 			localmetrics.IncValidationFailures("spec_validation")
 			// Update status to indicate validation failure
-			instance.Status.Conditions = controllerutil.UpdateCondition(instance.Status.Conditions, "SubjectPermission validation failed", []string{err.Error()}, true, managedv1alpha1.SubjectPermissionStateFailed, managedv1alpha1.ClusterRoleBindingCreated)
+			controllerutil.MarkDegraded(instance, managedv1alpha1.ReasonInvalidSpec, fmt.Sprintf("%s validation failed: %v", kind, err))
+			controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonValidationFailed, controllerutil.EventActionValidate, "%s validation failed: %v", kind, err)
 			if updateErr := r.Client.Status().Update(ctx, instance); updateErr != nil {
-				reqLogger.Error(updateErr, "Failed to update SubjectPermission status after validation failure")
+				reqLogger.Error(updateErr, "Failed to update "+kind+" status after validation failure")
 			}
-			return ctrl.Result{}, fmt.Errorf("SubjectPermission validation failed: %w", err)
+			return ctrl.Result{}, fmt.Errorf("%s validation failed: %w", kind, err)
 		}
 	}
 
-	// Handle deletion with finalizer (skip in test mode)
-	finalizer := "subjectpermission.managed.openshift.io/finalizer"
-	if !r.DisableFinalizers {
-		if instance.DeletionTimestamp != nil {
-			if ctrlutil.ContainsFinalizer(instance, finalizer) {
-				// Perform cleanup
-				reqLogger.Info("Cleaning up SubjectPermission resources", "name", instance.GetName())
-				localmetrics.DeletePrometheusMetric(instance)
-
-				// Remove finalizer to allow deletion
-				ctrlutil.RemoveFinalizer(instance, finalizer)
-				if err := r.Update(ctx, instance); err != nil {
-					result = "error"
-					localmetrics.IncReconcileErrors("subjectpermission", "cleanup")
-					return ctrl.Result{}, fmt.Errorf("failed to remove finalizer: %w", err)
-				}
-			}
-			return ctrl.Result{}, nil
+	// Add finalizer if not present (skip in test mode)
+	if !r.DisableFinalizers && !ctrlutil.ContainsFinalizer(instance, finalizer) {
+		ctrlutil.AddFinalizer(instance, finalizer)
+		if err := r.Update(ctx, instance); err != nil {
+			result = "error"
+			localmetrics.IncReconcileErrors("subjectpermission", "finalizer")
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
 		}
+		return ctrl.Result{RequeueAfter: time.Second}, nil
+	}
 
-		// Add finalizer if not present
-		if !ctrlutil.ContainsFinalizer(instance, finalizer) {
-			ctrlutil.AddFinalizer(instance, finalizer)
-			if err := r.Update(ctx, instance); err != nil {
-				result = "error"
-				localmetrics.IncReconcileErrors("subjectpermission", "finalizer")
-				return ctrl.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
-			}
-			return ctrl.Result{RequeueAfter: time.Second}, nil
-		}
-	} else {
-		// Simple cleanup for test mode
-		if instance.DeletionTimestamp != nil {
-			reqLogger.Info("Removing Prometheus metrics for SubjectPermission", "name", instance.GetName())
-			localmetrics.DeletePrometheusMetric(instance)
-			return ctrl.Result{}, nil
+	// the bindings of an expired SubjectPermission are revoked, it is kept to record the grant
+	if controllerutil.IsExpired(instance, time.Now()) {
+		if err := r.expire(ctx, instance, originalStatus); err != nil {
+			result = "error"
+			return ctrl.Result{}, err
 		}
+		result = "expired"
+		// nothing is granted until the expiration is extended, which triggers another reconcile
+		return ctrl.Result{}, nil
 	}
 
+	// the bindings withheld by the grant policy are revoked like the ones removed from the spec
+	cfg := r.Config.Get()
+	policy := cfg.Policy
+
 	// get list of clusterRole on k8s
 	clusterRoleList := &v1.ClusterRoleList{}
 	err = r.List(ctx, clusterRoleList)
@@ -154,76 +208,396 @@ func (r *SubjectPermissionReconciler) Reconcile(ctx context.Context, request ctr
 		return ctrl.Result{}, fmt.Errorf("failed to list ClusterRoles: %w", err)
 	}
 
-	// get a list of clusterRoleBinding from k8s cluster list
-	clusterRoleBindingList := &v1.ClusterRoleBindingList{}
-	err = r.List(ctx, clusterRoleBindingList)
+	// the ClusterRoles defined by the SubjectPermission come first, so its bindings find them
+	clusterRoleScope := r.reconcileClusterRoles(ctx, instance, clusterRoleList)
+
+	// the cluster scope and the namespace scope are both applied on every reconcile,
+	// bindings that cannot be applied are reported in the status instead of holding back the others
+	subjects := controllerutil.SubjectsOf(instance.GetSpec())
+	clusterScope, err := r.reconcileClusterPermissions(ctx, instance, subjects, clusterRoleList, policy)
 	if err != nil {
-		reqLogger.Error(err, "Failed to get clusterRoleBindingList")
 		result = "error"
-		localmetrics.IncReconcileErrors("subjectpermission", "list_clusterrolebindings")
-		return ctrl.Result{}, fmt.Errorf("failed to list ClusterRoleBindings: %w", err)
+		return ctrl.Result{}, err
+	}
+	namespaceScope, err := r.reconcileNamespacePermissions(ctx, instance, subjects, clusterRoleList, policy)
+	if err != nil {
+		result = "error"
+		return ctrl.Result{}, err
 	}
 
-	// get all ClusterRoleNames that do not exist as ClusterRole
-	clusterRoleNamesNotOnCluster := PopulateCrClusterRoleNames(instance, clusterRoleList)
-	if len(clusterRoleNamesNotOnCluster) != 0 {
-		// update condition if any ClusterRoleName does not exist as a ClusterRole
-		instance.Status.Conditions = controllerutil.UpdateCondition(instance.Status.Conditions, "ClusterRole for ClusterPermission does not exist", clusterRoleNamesNotOnCluster, true, managedv1alpha1.SubjectPermissionStateFailed, managedv1alpha1.ClusterRoleBindingCreated)
+	// compute the status once from the result of both scopes
+	var problems []string
+	if len(namespaceScope.invalidPermissions) != 0 {
+		problems = append(problems, "Invalid namespace patterns: "+strings.Join(namespaceScope.invalidPermissions, ", "))
+	}
+	if len(clusterRoleScope.conflictingClusterRoles) != 0 {
+		problems = append(problems, namesMessage("ClusterRole already exists and was not created for the "+kind, clusterRoleScope.conflictingClusterRoles))
+	}
+	if len(clusterRoleScope.failures) != 0 {
+		problems = append(problems, fmt.Sprintf("Failed to apply %d ClusterRoles: %v", len(clusterRoleScope.failures), utilerrors.NewAggregate(clusterRoleScope.failures)))
+	}
+	if len(clusterScope.missingClusterRoles) != 0 {
+		problems = append(problems, namesMessage("ClusterRole for ClusterPermission does not exist", clusterScope.missingClusterRoles))
+	}
+	if len(namespaceScope.missingClusterRoles) != 0 {
+		problems = append(problems, namesMessage("Role for Permission does not exist", namespaceScope.missingClusterRoles))
+	}
+	missingClusterRoles := len(clusterScope.missingClusterRoles) != 0 || len(namespaceScope.missingClusterRoles) != 0
+	if len(namespaceScope.missingRoles) != 0 {
+		problems = append(problems, namesMessage("Role for Permission does not exist in some namespaces, see status.permissions", namespaceScope.missingRoles))
+	}
+	deniedClusterRoles := append(clusterScope.deniedClusterRoles, namespaceScope.deniedClusterRoles...)
+	if len(deniedClusterRoles) != 0 {
+		problems = append(problems, namesMessage("ClusterRole denied by the operator policy", deniedClusterRoles))
+	}
+	policyViolation := len(deniedClusterRoles) != 0
+	bindingFailures := append(clusterScope.failures, namespaceScope.failures...)
+	if len(bindingFailures) != 0 {
+		problems = append(problems, fmt.Sprintf("Failed to apply %d bindings, see status.permissions for the failing namespaces", len(bindingFailures)))
+	}
+	failures := append(clusterRoleScope.failures, bindingFailures...)
+	switch {
+	case len(namespaceScope.invalidPermissions) != 0:
+		// the spec has to be fixed, this is not retried until it changes
+		controllerutil.MarkDegraded(instance, managedv1alpha1.ReasonInvalidSpec, strings.Join(problems, "; "))
+	case len(failures) != 0:
+		controllerutil.MarkDegraded(instance, managedv1alpha1.ReasonBindingsFailed, strings.Join(problems, "; "))
+		// the bindings that failed are retried with backoff
+		controllerutil.UpdateCondition(instance, managedv1alpha1.ConditionProgressing, metav1.ConditionTrue, managedv1alpha1.ReasonBindingsFailed, "Retrying the bindings that could not be applied")
+	case len(clusterRoleScope.conflictingClusterRoles) != 0:
+		// the bindings reference the existing ClusterRole, which is left to its owner
+		controllerutil.MarkDegraded(instance, managedv1alpha1.ReasonClusterRoleConflict, strings.Join(problems, "; "))
+	case policyViolation:
+		// the spec or the policy has to change, the bindings allowed by the policy are in place
+		controllerutil.MarkDegraded(instance, managedv1alpha1.ReasonPolicyViolation, strings.Join(problems, "; "))
+	case missingClusterRoles:
+		controllerutil.MarkDegraded(instance, managedv1alpha1.ReasonClusterRoleNotFound, strings.Join(problems, "; "))
+	case len(namespaceScope.missingRoles) != 0:
+		controllerutil.MarkDegraded(instance, managedv1alpha1.ReasonRoleNotFound, strings.Join(problems, "; "))
+	case isDryRun(instance):
+		controllerutil.MarkDryRun(instance, "Bindings are not applied in DryRun mode, see status.plan for the changes")
+	default:
+		controllerutil.MarkReady(instance, "All ClusterRoleBindings and RoleBindings are in place")
+	}
+	if isDryRun(instance) {
+		// nothing was applied, the bindings in place are reported as they were
+		plan := &managedv1alpha1.SubjectPermissionPlan{
+			ClusterRoleBindings: clusterScope.changes,
+			RoleBindings:        namespaceScope.changes,
+			ClusterRoles:        clusterRoleScope.changes,
+		}
+		controllerutil.TruncatePlannedChanges(&plan.ClusterRoleBindings)
+		controllerutil.TruncatePlannedChanges(&plan.RoleBindings)
+		controllerutil.TruncatePlannedChanges(&plan.ClusterRoles)
+		instance.GetStatus().Plan = plan
+	} else {
+		instance.GetStatus().Subjects = controllerutil.UpdateSubjectStatuses(instance.GetStatus().Subjects, subjects, clusterScope.subjectBindings, namespaceScope.subjectBindings)
+		instance.GetStatus().ClusterRoleBindings = sortedNames(clusterScope.clusterRoleBindings)
+		instance.GetStatus().ClusterRoles = sortedNames(clusterRoleScope.clusterRoles)
+		instance.GetStatus().Permissions = namespaceScope.permissions
+		instance.GetStatus().Plan = nil
+	}
+	// the Warning Events of the ClusterRoles listed here are only emitted when they are first found missing or denied
+	instance.GetStatus().MissingClusterRoles = uniqueSortedNames(append(clusterScope.missingClusterRoles, namespaceScope.missingClusterRoles...))
+	instance.GetStatus().DeniedClusterRoles = uniqueSortedNames(deniedClusterRoles)
+	instance.GetStatus().ExpiresAt = controllerutil.ExpirationOf(instance)
+
+	// only write the status when it changed to avoid reconciling again
+	if !equality.Semantic.DeepEqual(originalStatus, instance.GetStatus()) {
 		err = r.Client.Status().Update(ctx, instance)
 		if err != nil {
-			reqLogger.Error(err, "Failed to update condition in subjectpermission controller when checking ClusterRolenames that do not exist as ClusterRole")
+			reqLogger.Error(err, "Failed to update "+kind+" status")
 			result = "error"
 			localmetrics.IncReconcileErrors("subjectpermission", "status_update")
-			return ctrl.Result{}, fmt.Errorf("failed to update status for missing ClusterRoles: %w", err)
+			return ctrl.Result{}, fmt.Errorf("failed to update %s status: %w", kind, err)
 		}
-		// exit reconcile, wait for next CR change
+	}
+
+	if len(failures) != 0 {
+		result = "error"
+		return ctrl.Result{}, utilerrors.NewAggregate(failures)
+	}
+	if len(namespaceScope.invalidPermissions) != 0 {
+		result = "validation_error"
+	} else if len(clusterRoleScope.conflictingClusterRoles) != 0 {
+		result = "clusterrole_conflict"
+	} else if policyViolation {
+		result = "policy_violation"
+	} else if missingClusterRoles {
+		// the bindings of the missing ClusterRoles are applied once they are created
 		result = "missing_clusterroles"
-		return ctrl.Result{}, nil
+	} else if len(problems) != 0 {
+		result = "missing_roles"
+	}
+	// namespaces and bindings changed behind the watches are caught up with on the next resync
+	requeueAfter := cfg.ResyncPeriod
+	if expiresAt := instance.GetStatus().ExpiresAt; expiresAt != nil {
+		localmetrics.SetExpirationMetric(instance, expiresAt.Time, false)
+		// wake up when the SubjectPermission expires to revoke its bindings on time
+		if untilExpiration := time.Until(expiresAt.Time); requeueAfter == 0 || untilExpiration < requeueAfter {
+			requeueAfter = untilExpiration
+		}
+	} else {
+		localmetrics.DeleteExpirationMetric(instance)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// expire revokes every binding and ClusterRole created for the expired SubjectPermission and marks it Expired.
+// Nothing is revoked in DryRun mode
+func (r *SubjectPermissionReconciler) expire(ctx context.Context, instance managedv1alpha1.SubjectPermissionObject, originalStatus *managedv1alpha1.SubjectPermissionStatus) error {
+	kind := kindOf(instance)
+	expiresAt := controllerutil.ExpirationOf(instance)
+	if isDryRun(instance) {
+		controllerutil.MarkExpired(instance, fmt.Sprintf("%s expired at %s, bindings are not revoked in DryRun mode", kind, expiresAt.UTC().Format(time.RFC3339)))
+	} else {
+		if err := r.cleanupBindings(ctx, instance); err != nil {
+			localmetrics.IncReconcileErrors("subjectpermission", "expire")
+			controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonBindingFailed, controllerutil.EventActionRevoke, "Failed to revoke the bindings of the expired %s: %v", kind, err)
+			return fmt.Errorf("failed to revoke the bindings of the expired %s: %w", kind, err)
+		}
+		controllerutil.MarkExpired(instance, fmt.Sprintf("%s expired at %s, its bindings were revoked", kind, expiresAt.UTC().Format(time.RFC3339)))
+	}
+	status := instance.GetStatus()
+	status.Subjects = nil
+	status.ClusterRoleBindings = nil
+	status.ClusterRoles = nil
+	status.MissingClusterRoles = nil
+	status.DeniedClusterRoles = nil
+	status.Permissions = nil
+	status.Plan = nil
+	status.ExpiresAt = expiresAt
+
+	// the expiration is only reported once, the next reconciles revoke the bindings recreated behind the operator
+	if ready := meta.FindStatusCondition(originalStatus.Conditions, managedv1alpha1.ConditionReady); ready == nil || ready.Reason != managedv1alpha1.ReasonExpired {
+		log.Info(kind+" expired", "namespace", instance.GetNamespace(), "name", instance.GetName(), "expiresAt", expiresAt)
+		controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeNormal, controllerutil.EventReasonExpired, controllerutil.EventActionRevoke, "%s expired at %s", kind, expiresAt.UTC().Format(time.RFC3339))
+		localmetrics.IncExpirations(instance)
+	}
+	localmetrics.SetExpirationMetric(instance, expiresAt.Time, true)
+
+	if !equality.Semantic.DeepEqual(originalStatus, status) {
+		if err := r.Client.Status().Update(ctx, instance); err != nil {
+			localmetrics.IncReconcileErrors("subjectpermission", "status_update")
+			return fmt.Errorf("failed to update %s status: %w", kind, err)
+		}
+	}
+	return nil
+}
+
+// scopeResult is the outcome of applying the bindings of a SubjectPermission in one scope
+type scopeResult struct {
+	// subjectBindings counts the bindings in place for each Subject, by SubjectKey
+	subjectBindings map[string]int
+	// clusterRoleBindings lists the ClusterRoleBindings in place
+	clusterRoleBindings []string
+	// clusterRoles lists the ClusterRoles in place that were created for the SubjectPermission
+	clusterRoles []string
+	// conflictingClusterRoles lists the ClusterRoles defined by the SubjectPermission that exist and were not created for it
+	conflictingClusterRoles []string
+	// permissions reports the RoleBindings in place for each Permission
+	permissions []managedv1alpha1.PermissionStatus
+	// missingClusterRoles lists the referenced ClusterRoles that do not exist
+	missingClusterRoles []string
+	// missingRoles describes the Roles of Permissions missing from some allowed namespaces
+	missingRoles []string
+	// deniedClusterRoles lists the referenced ClusterRoles the grant policy does not allow in the scope
+	deniedClusterRoles []string
+	// invalidPermissions describes the Permissions whose namespace patterns cannot be compiled
+	invalidPermissions []string
+	// changes lists the bindings that would be changed, only set in DryRun mode
+	changes managedv1alpha1.BindingChanges
+	// failures holds the errors of the bindings that could not be applied or revoked
+	failures []error
+}
+
+// kindOf returns the kind of the SubjectPermission object, for logs and errors
+func kindOf(sp managedv1alpha1.SubjectPermissionObject) string {
+	if _, ok := sp.(*managedv1alpha1.ClusterSubjectPermission); ok {
+		return "ClusterSubjectPermission"
 	}
+	return "SubjectPermission"
+}
+
+// isDryRun checks if the bindings of the SubjectPermission are only planned instead of applied
+func isDryRun(sp managedv1alpha1.SubjectPermissionObject) bool {
+	return sp.GetSpec().Mode == managedv1alpha1.ModeDryRun
+}
+
+// reconcileClusterRoles applies the ClusterRoles defined in the spec of the SubjectPermission and deletes the ones it
+// created that are no longer defined. A ClusterRole of the same name that was not created for the SubjectPermission is
+// left untouched. The ClusterRoles created are added to clusterRoleList, so their bindings are not withheld until the
+// cache catches up
+func (r *SubjectPermissionReconciler) reconcileClusterRoles(ctx context.Context, instance managedv1alpha1.SubjectPermissionObject, clusterRoleList *v1.ClusterRoleList) *scopeResult {
+	reqLogger := log.WithValues("Request.Namespace", instance.GetNamespace(), "Request.Name", instance.GetName())
+	kind := kindOf(instance)
 
-	// for every ClusterPermission
-	var createdClusterRoleBindingCount int
-	var createdClusterRoleBinding bool
-	var clusterRoleNames []string
-	for _, clusterRoleName := range instance.Spec.ClusterPermissions {
-		// create a new ClusterRoleBinding
-		newCRB := NewClusterRoleBinding(clusterRoleName, instance.Spec.SubjectName, instance.Spec.SubjectKind)
-		err := r.Create(ctx, newCRB)
+	res := &scopeResult{}
+	desiredClusterRoles := map[string]bool{}
+	for _, definition := range instance.GetSpec().ClusterRoles {
+		desiredClusterRoles[definition.Name] = true
+		clusterRole := controllerutil.NewManagedClusterRole(instance, definition)
+		existing := controllerutil.FindClusterRole(definition.Name, clusterRoleList)
+		if existing != nil && !controllerutil.IsOwnedBy(existing, instance) {
+			reqLogger.Info("ClusterRole already exists and was not created for the "+kind, "clusterRoleName", definition.Name)
+			controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonClusterRoleConflict, controllerutil.EventActionGrant, "ClusterRole %s already exists and was not created for the %s, it is left untouched", definition.Name, kind)
+			res.conflictingClusterRoles = append(res.conflictingClusterRoles, definition.Name)
+			continue
+		}
+		if isDryRun(instance) {
+			controllerutil.RecordPlannedChange(&res.changes, controllerutil.PlanClusterRole(clusterRole, existing), definition.Name)
+			if existing == nil {
+				clusterRoleList.Items = append(clusterRoleList.Items, *clusterRole)
+			}
+			continue
+		}
+		op, err := controllerutil.EnsureClusterRole(ctx, r.Client, clusterRole, existing)
 		if err != nil {
-			if !k8serr.IsAlreadyExists(err) {
-				reqLogger.Error(err, "Failed to create ClusterRoleBinding", "clusterRoleName", clusterRoleName, "subjectName", instance.Spec.SubjectName)
-				result = "error"
+			reqLogger.Error(err, "Failed to apply ClusterRole", "clusterRoleName", definition.Name)
+			localmetrics.IncReconcileErrors("subjectpermission", "apply_clusterrole")
+			controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonClusterRoleFailed, controllerutil.EventActionGrant, "Failed to apply ClusterRole %s: %v", definition.Name, err)
+			res.failures = append(res.failures, fmt.Errorf("failed to apply ClusterRole %s: %w", definition.Name, err))
+			continue
+		}
+		switch op {
+		case ctrlutil.OperationResultCreated:
+			reqLogger.Info("ClusterRole created successfully", "clusterRoleName", definition.Name)
+			localmetrics.IncResourcesCreated("ClusterRole", instance.GetName())
+			controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeNormal, controllerutil.EventReasonClusterRoleCreated, controllerutil.EventActionGrant, "Created ClusterRole %s", definition.Name)
+		case ctrlutil.OperationResultUpdated:
+			reqLogger.Info("ClusterRole restored to desired state", "clusterRoleName", definition.Name)
+			controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeNormal, controllerutil.EventReasonClusterRoleRestored, controllerutil.EventActionGrant, "Restored ClusterRole %s to its desired state", definition.Name)
+		}
+		if existing == nil {
+			clusterRoleList.Items = append(clusterRoleList.Items, *clusterRole)
+		}
+		res.clusterRoles = append(res.clusterRoles, definition.Name)
+	}
+
+	if isDryRun(instance) {
+		for _, clusterRole := range staleClusterRoles(instance, clusterRoleList, desiredClusterRoles) {
+			controllerutil.RecordPlannedDeletion(&res.changes, clusterRole.Name)
+		}
+		return res
+	}
+
+	if err := r.revokeClusterRoles(ctx, instance, clusterRoleList, desiredClusterRoles); err != nil {
+		reqLogger.Error(err, "Failed to revoke ClusterRoles")
+		localmetrics.IncReconcileErrors("subjectpermission", "delete_clusterrole")
+		controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonClusterRoleFailed, controllerutil.EventActionRevoke, "Failed to revoke ClusterRoles: %v", err)
+		res.failures = append(res.failures, fmt.Errorf("failed to revoke ClusterRoles: %w", err))
+	}
+	return res
+}
+
+// reconcileClusterPermissions applies a ClusterRoleBinding for every ClusterPermission and Subject of the
+// SubjectPermission allowed by the policy, and revokes the ClusterRoleBindings that are no longer required.
+// An error is only returned when the ClusterRoleBindings cannot be listed, other failures are part of the result
+func (r *SubjectPermissionReconciler) reconcileClusterPermissions(ctx context.Context, instance managedv1alpha1.SubjectPermissionObject, subjects []v1.Subject, clusterRoleList *v1.ClusterRoleList, policy *controllerutil.GrantPolicy) (*scopeResult, error) {
+	reqLogger := log.WithValues("Request.Namespace", instance.GetNamespace(), "Request.Name", instance.GetName())
+
+	// get a list of clusterRoleBinding from k8s cluster list
+	clusterRoleBindingList := &v1.ClusterRoleBindingList{}
+	err := r.List(ctx, clusterRoleBindingList)
+	if err != nil {
+		reqLogger.Error(err, "Failed to get clusterRoleBindingList")
+		localmetrics.IncReconcileErrors("subjectpermission", "list_clusterrolebindings")
+		return nil, fmt.Errorf("failed to list ClusterRoleBindings: %w", err)
+	}
+
+	res := &scopeResult{subjectBindings: map[string]int{}}
+	// get all ClusterRoleNames that do not exist as ClusterRole
+	res.missingClusterRoles = PopulateCrClusterRoleNames(instance, clusterRoleList)
+	for _, clusterRoleName := range sortedNames(res.missingClusterRoles) {
+		// the ClusterRoles reported missing by the previous reconcile are not reported again
+		if slices.Contains(instance.GetStatus().MissingClusterRoles, clusterRoleName) {
+			continue
+		}
+		controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonClusterRoleMissing, controllerutil.EventActionGrant, "ClusterRole %s for ClusterPermission does not exist", clusterRoleName)
+	}
+
+	// for every ClusterPermission and every Subject
+	desiredClusterRoleBindings := map[string]bool{}
+	for _, clusterRoleName := range instance.GetSpec().ClusterPermissions {
+		// a ClusterRoleBinding withheld by the policy is not desired, an existing one is revoked
+		if !policy.PermitsClusterRoleOf(instance.GetSpec(), clusterRoleName) {
+			reqLogger.Info("ClusterRole denied at cluster scope by the grant policy", "clusterRoleName", clusterRoleName)
+			localmetrics.IncPolicyViolations("cluster_scope")
+			if !slices.Contains(instance.GetStatus().DeniedClusterRoles, clusterRoleName) {
+				controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonPolicyViolation, controllerutil.EventActionGrant, "ClusterRole %s denied at cluster scope by the operator policy", clusterRoleName)
+			}
+			res.deniedClusterRoles = append(res.deniedClusterRoles, clusterRoleName)
+			continue
+		}
+		missing := slices.Contains(res.missingClusterRoles, clusterRoleName)
+		for _, subject := range subjects {
+			newCRB := NewClusterRoleBinding(instance, clusterRoleName, subject.Name, subject.Namespace, subject.Kind)
+			controllerutil.SetOwnershipMetadata(newCRB, instance, clusterRoleName)
+			crbName := newCRB.Name
+			desiredClusterRoleBindings[crbName] = true
+			// the ClusterRoleBinding is created once the ClusterRole exists, an existing one is kept meanwhile
+			if missing {
+				continue
+			}
+			existingCRB := controllerutil.FindClusterRoleBinding(crbName, clusterRoleBindingList)
+			if isDryRun(instance) {
+				controllerutil.RecordPlannedChange(&res.changes, controllerutil.PlanClusterRoleBinding(newCRB, existingCRB), crbName)
+				continue
+			}
+			op, err := controllerutil.EnsureClusterRoleBinding(ctx, r.Client, newCRB, existingCRB)
+			if err != nil {
+				reqLogger.Error(err, "Failed to create ClusterRoleBinding", "clusterRoleName", clusterRoleName, "subjectName", subject.Name)
 				localmetrics.IncReconcileErrors("subjectpermission", "create_clusterrolebinding")
-				return ctrl.Result{}, fmt.Errorf("failed to create ClusterRoleBinding for %s: %w", clusterRoleName, err)
+				controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonBindingFailed, controllerutil.EventActionGrant, "Failed to apply ClusterRoleBinding %s: %v", crbName, err)
+				res.failures = append(res.failures, fmt.Errorf("failed to create ClusterRoleBinding %s: %w", crbName, err))
+				continue
 			}
-		} else {
-			clusterRoleBindingName := fmt.Sprintf("%s-%s", clusterRoleName, instance.Spec.SubjectName)
-			reqLogger.Info("ClusterRoleBinding created successfully", "name", clusterRoleBindingName, "clusterRoleName", clusterRoleName, "subject", instance.Spec.SubjectName)
-			localmetrics.IncResourcesCreated("ClusterRoleBinding", instance.Spec.SubjectName)
-			// Created the ClusterRoleBinding, update status later
-			createdClusterRoleBinding = true
-		}
-		// if ClusterRoleBinding created successfully OR ClusterRoleBinding already exists on cluster, add one to counter and append
-		clusterRoleNames = append(clusterRoleNames, clusterRoleName)
-		createdClusterRoleBindingCount++
-	}
-	// updateCondition if all ClusterRoleBindings added successfully
-	if createdClusterRoleBinding && len(instance.Spec.ClusterPermissions) == createdClusterRoleBindingCount {
-		instance.Status.Conditions = controllerutil.UpdateCondition(instance.Status.Conditions, "Successfully created all ClusterRoleBindings", clusterRoleNames, true, managedv1alpha1.SubjectPermissionStateCreated, managedv1alpha1.ClusterRoleBindingCreated)
-		err = r.Client.Status().Update(ctx, instance)
-		if err != nil {
-			reqLogger.Error(err, "Failed to update condition in subjectpermission controller when successfully created all cluster role bindings")
-			return ctrl.Result{}, err
+			switch op {
+			case ctrlutil.OperationResultCreated:
+				reqLogger.Info("ClusterRoleBinding created successfully", "name", crbName, "clusterRoleName", clusterRoleName, "subject", subject.Name)
+				localmetrics.IncResourcesCreated("ClusterRoleBinding", subject.Name)
+				controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeNormal, controllerutil.EventReasonBindingCreated, controllerutil.EventActionGrant, "Created ClusterRoleBinding %s granting ClusterRole %s to %s", crbName, clusterRoleName, controllerutil.SubjectKey(subject))
+			case ctrlutil.OperationResultUpdated:
+				reqLogger.Info("ClusterRoleBinding restored to desired state", "name", crbName, "clusterRoleName", clusterRoleName, "subject", subject.Name)
+				controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeNormal, controllerutil.EventReasonBindingRestored, controllerutil.EventActionGrant, "Restored ClusterRoleBinding %s to its desired state", crbName)
+			}
+			// the ClusterRoleBinding was created successfully OR already exists on cluster
+			res.clusterRoleBindings = append(res.clusterRoleBindings, crbName)
+			res.subjectBindings[controllerutil.SubjectKey(subject)]++
 		}
-		return ctrl.Result{}, nil
 	}
 
+	if isDryRun(instance) {
+		for _, crb := range staleClusterRoleBindings(instance, clusterRoleBindingList, desiredClusterRoleBindings) {
+			controllerutil.RecordPlannedDeletion(&res.changes, crb.Name)
+		}
+		return res, nil
+	}
+
+	// remove ClusterRoleBindings that are no longer required by the ClusterPermissions
+	err = r.revokeClusterRoleBindings(ctx, instance, clusterRoleBindingList, desiredClusterRoleBindings)
+	if err != nil {
+		reqLogger.Error(err, "Failed to revoke ClusterRoleBindings")
+		localmetrics.IncReconcileErrors("subjectpermission", "delete_clusterrolebinding")
+		controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonBindingFailed, controllerutil.EventActionRevoke, "Failed to revoke ClusterRoleBindings: %v", err)
+		res.failures = append(res.failures, fmt.Errorf("failed to revoke ClusterRoleBindings: %w", err))
+	}
+	return res, nil
+}
+
+// reconcileNamespacePermissions applies a RoleBinding for every Permission, allowed Namespace and Subject of the
+// SubjectPermission allowed by the policy, and revokes the RoleBindings that are no longer required.
+// An error is only returned when the Namespaces or RoleBindings cannot be listed, other failures are part of the result
+func (r *SubjectPermissionReconciler) reconcileNamespacePermissions(ctx context.Context, instance managedv1alpha1.SubjectPermissionObject, subjects []v1.Subject, clusterRoleList *v1.ClusterRoleList, policy *controllerutil.GrantPolicy) (*scopeResult, error) {
+	reqLogger := log.WithValues("Request.Namespace", instance.GetNamespace(), "Request.Name", instance.GetName())
+
 	// get the NamespaceList
 	nsList := &corev1.NamespaceList{}
-	err = r.List(ctx, nsList)
+	err := r.List(ctx, nsList)
 	if err != nil {
 		reqLogger.Error(err, "Failed to get NamespaceList")
-		return ctrl.Result{}, err
+		localmetrics.IncReconcileErrors("subjectpermission", "list_namespaces")
+		return nil, fmt.Errorf("failed to list Namespaces: %w", err)
 	}
 
 	// eliminate terminating and non existing Namespace from the nsList.Items
@@ -236,89 +610,347 @@ func (r *SubjectPermissionReconciler) Reconcile(ctx context.Context, request ctr
 		}
 	}
 
-	if len(instance.Spec.Permissions) != 0 {
-		var CreatedRoleBindingCount int
-		var successfullRoleBindingNames []string
-		// compile list of allowed namespaces only for this subject permission. NOT a list of subject permissions
-		for _, permission := range instance.Spec.Permissions {
-			// get all ClusterRoleNames that does not exists as RoleNames
-			clusterRoleNamesForPermissionNotOnCluster := controllerutil.PopulateCrPermissionClusterRoleNames(instance, clusterRoleList)
-			if len(clusterRoleNamesForPermissionNotOnCluster) != 0 {
-				// update condition if any ClusterRoleName does not exist as a Role
-				instance.Status.Conditions = controllerutil.UpdateCondition(instance.Status.Conditions, "Role for Permission does not exist", clusterRoleNamesForPermissionNotOnCluster, true, managedv1alpha1.SubjectPermissionStateFailed, managedv1alpha1.RoleBindingCreated)
-				err = r.Client.Status().Update(ctx, instance)
-				if err != nil {
-					reqLogger.Error(err, "Failed to update condition in subjectpermission controller when successfully created all cluster role bindings")
-					return ctrl.Result{}, err
-				}
-				// exit reconcile, wait for next CR change
-				return ctrl.Result{}, nil
+	// get the RoleBindings on the cluster once, instead of per namespace
+	roleBindingList := &v1.RoleBindingList{}
+	err = r.List(ctx, roleBindingList)
+	if err != nil {
+		reqLogger.Error(err, "Failed to get RoleBindingList")
+		localmetrics.IncReconcileErrors("subjectpermission", "list_rolebindings")
+		return nil, fmt.Errorf("failed to list RoleBindings: %w", err)
+	}
+
+	// the Roles of the Permissions are looked up in each allowed namespace
+	roles, err := r.listRoles(ctx, instance)
+	if err != nil {
+		reqLogger.Error(err, "Failed to get RoleList")
+		localmetrics.IncReconcileErrors("subjectpermission", "list_roles")
+		return nil, err
+	}
+
+	res := &scopeResult{subjectBindings: map[string]int{}}
+	// get all ClusterRoleNames that does not exists as RoleNames
+	res.missingClusterRoles = controllerutil.PopulateCrPermissionClusterRoleNames(instance, clusterRoleList)
+	for _, clusterRoleName := range sortedNames(res.missingClusterRoles) {
+		// the ClusterRoles reported missing by the previous reconcile are not reported again
+		if slices.Contains(instance.GetStatus().MissingClusterRoles, clusterRoleName) {
+			continue
+		}
+		controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonClusterRoleMissing, controllerutil.EventActionGrant, "ClusterRole %s for Permission does not exist", clusterRoleName)
+	}
+	// the Events of RoleBindings are emitted on their Namespace as well
+	namespaces := controllerutil.NamespaceIndex(&newNsList)
+
+	desiredRoleBindings := map[types.NamespacedName]bool{}
+	skipRevoke := false
+	// compile list of allowed namespaces only for this subject permission. NOT a list of subject permissions
+	for _, permission := range instance.GetSpec().Permissions {
+		role := permission.Role()
+		permissionStatus := controllerutil.PermissionStatusFor(&res.permissions, role)
+		missing := !role.IsRole() && slices.Contains(res.missingClusterRoles, role.Name)
+
+		// the RoleBindings withheld by the policy are not desired, existing ones are revoked.
+		// The policy limits the ClusterRoles, the Roles belong to their namespace
+		if !role.IsRole() && !policy.PermitsNamespacedClusterRoleOf(instance.GetSpec(), role.Name) {
+			reqLogger.Info("ClusterRole denied at namespace scope by the grant policy", "clusterRoleName", role.Name)
+			localmetrics.IncPolicyViolations("namespace_scope")
+			if !slices.Contains(instance.GetStatus().DeniedClusterRoles, role.Name) {
+				controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonPolicyViolation, controllerutil.EventActionGrant, "ClusterRole %s denied at namespace scope by the operator policy", role.Name)
 			}
+			res.deniedClusterRoles = append(res.deniedClusterRoles, role.Name)
+			continue
+		}
+
+		// the regexes and selectors are compiled once per Permission, a bad pattern is reported instead of retried
+		matcher, err := controllerutil.NewPermissionMatcher(permission)
+		if err != nil {
+			// without knowing every desired RoleBinding, nothing can be revoked safely
+			reqLogger.Error(err, "Failed to match namespaces", "role", role.String())
+			localmetrics.IncReconcileErrors("subjectpermission", "namespace_selector")
+			res.invalidPermissions = append(res.invalidPermissions, fmt.Sprintf("Permission for %s: %v", role, err))
+			controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonValidationFailed, controllerutil.EventActionValidate, "Invalid namespace patterns in the Permission for %s: %v", role, err)
+			skipRevoke = true
+			continue
+		}
 
-			// list of all namespaces in safelist
-			safeList := controllerutil.GenerateSafeList(permission.NamespacesAllowedRegex, permission.NamespacesDeniedRegex, &newNsList)
+		// list of all namespaces in safelist, the namespaces protected by the grant policy are excluded
+		// and reported, existing RoleBindings in them are revoked
+		safeList, protected := matcher.SafeList(&newNsList, policy)
+		previous := controllerutil.FindPermissionStatus(instance.GetStatus().Permissions, role)
+		for _, ns := range protected {
+			controllerutil.AddProtectedNamespace(permissionStatus, ns)
+			// only the namespaces that were not protected on the previous reconcile are reported
+			if previous == nil || !slices.Contains(previous.ProtectedNamespaces, ns) && len(previous.ProtectedNamespaces) < managedv1alpha1.MaxProtectedNamespaces {
+				controllerutil.RecordEvent(r.Recorder, instance, namespaces[ns], corev1.EventTypeNormal, controllerutil.EventReasonNamespaceExcluded, controllerutil.EventActionGrant, "Namespace %s is protected by the operator policy, %s %s is not granted in it", ns, role.Kind, role.Name)
+			}
+		}
 
-			var namespaceCount int
-			// for each safelisted namespace
-			for _, ns := range safeList {
-				// get a list of all rolebindings in namespace
-				rbList := &v1.RoleBindingList{}
-				opts := []client.ListOption{
-					client.InNamespace(ns),
+		// for each safelisted namespace and every Subject
+		missingRoleNamespaces := 0
+		for _, ns := range safeList {
+			// the RoleBindings of a Role are created once the namespace holds the Role, existing ones are kept meanwhile
+			roleMissing := role.IsRole() && !roles[types.NamespacedName{Namespace: ns, Name: role.Name}]
+			if roleMissing {
+				missingRoleNamespaces++
+				controllerutil.AddMissingRoleNamespace(permissionStatus, ns)
+				// only the namespaces that held the Role on the previous reconcile are reported
+				if previous == nil || !slices.Contains(previous.MissingRoleNamespaces, ns) && len(previous.MissingRoleNamespaces) < managedv1alpha1.MaxMissingRoleNamespaces {
+					controllerutil.RecordEvent(r.Recorder, instance, namespaces[ns], corev1.EventTypeWarning, controllerutil.EventReasonRoleMissing, controllerutil.EventActionGrant, "Role %s for Permission does not exist in namespace %s", role.Name, ns)
 				}
-				// TODO: Check error
-				_ = r.List(ctx, rbList, opts...)
-
+			}
+			for _, subject := range subjects {
 				// create roleBinding
-				roleBinding := controllerutil.NewRoleBindingForClusterRole(permission.ClusterRoleName, instance.Spec.SubjectName, instance.Spec.SubjectNamespace, instance.Spec.SubjectKind, ns)
+				roleBinding := controllerutil.NewRoleBinding(instance, role, subject.Name, subject.Namespace, subject.Kind, ns)
+				controllerutil.SetOwnershipMetadata(roleBinding, instance, role.String())
+				desiredRoleBindings[types.NamespacedName{Namespace: ns, Name: roleBinding.Name}] = true
+				// the RoleBinding is created once the ClusterRole exists, an existing one is kept meanwhile
+				if missing || roleMissing {
+					continue
+				}
 
-				err := r.Create(ctx, roleBinding)
+				existingRB := controllerutil.FindRoleBinding(ns, roleBinding.Name, roleBindingList)
+				if isDryRun(instance) {
+					controllerutil.RecordPlannedChange(&res.changes, controllerutil.PlanRoleBinding(roleBinding, existingRB), ns+"/"+roleBinding.Name)
+					continue
+				}
+				op, err := controllerutil.EnsureRoleBinding(ctx, r.Client, roleBinding, existingRB)
 				if err != nil {
-					if k8serr.IsAlreadyExists(err) {
-						continue
-					}
-
-					return ctrl.Result{}, err
+					// keep going for the other namespaces, the failure is reported in the status
+					reqLogger.Error(err, "Failed to apply RoleBinding", "name", roleBinding.Name, "namespace", ns)
+					localmetrics.IncReconcileErrors("subjectpermission", "create_rolebinding")
+					controllerutil.RecordNamespaceFailure(permissionStatus, ns, err.Error())
+					controllerutil.RecordEvent(r.Recorder, instance, namespaces[ns], corev1.EventTypeWarning, controllerutil.EventReasonBindingFailed, controllerutil.EventActionGrant, "Failed to apply RoleBinding %s in namespace %s: %v", roleBinding.Name, ns, err)
+					res.failures = append(res.failures, fmt.Errorf("failed to create RoleBinding %s in namespace %s: %w", roleBinding.Name, ns, err))
+					continue
+				}
+				res.subjectBindings[controllerutil.SubjectKey(subject)]++
+				permissionStatus.RoleBindings++
+				switch op {
+				case ctrlutil.OperationResultCreated:
+					// log each successfully created RoleBinding
+					reqLogger.Info(fmt.Sprintf("Successfully created RoleBinding %s in namespace %s", roleBinding.Name, ns))
+					controllerutil.RecordEvent(r.Recorder, instance, namespaces[ns], corev1.EventTypeNormal, controllerutil.EventReasonBindingCreated, controllerutil.EventActionGrant, "Created RoleBinding %s in namespace %s granting %s %s to %s", roleBinding.Name, ns, role.Kind, role.Name, controllerutil.SubjectKey(subject))
+				case ctrlutil.OperationResultUpdated:
+					reqLogger.Info("RoleBinding restored to desired state", "name", roleBinding.Name, "namespace", ns, "subject", subject.Name)
+					controllerutil.RecordEvent(r.Recorder, instance, namespaces[ns], corev1.EventTypeNormal, controllerutil.EventReasonBindingRestored, controllerutil.EventActionGrant, "Restored RoleBinding %s in namespace %s to its desired state", roleBinding.Name, ns)
 				}
-				successfullRoleBindingNames = append(successfullRoleBindingNames, permission.ClusterRoleName)
-
-				// log each successfully created ClusterRoleBinding
-				reqLogger.Info(fmt.Sprintf("Successfully created RoleBinding %s in namespace %s", roleBinding.Name, ns))
-				namespaceCount++
-			}
-			if len(safeList) != 0 && len(safeList) == namespaceCount {
-				//increment roleBindingCounter
-				CreatedRoleBindingCount++
 			}
 		}
+		if missingRoleNamespaces != 0 {
+			res.missingRoles = append(res.missingRoles, fmt.Sprintf("%s in %d namespaces", role.Name, missingRoleNamespaces))
+		}
+	}
 
-		if len(instance.Spec.Permissions) == CreatedRoleBindingCount {
-			// update condition if all RoleBindings added successfully
-			instance.Status.Conditions = controllerutil.UpdateCondition(instance.Status.Conditions, "Successfully created all roleBindings", successfullRoleBindingNames, true, managedv1alpha1.SubjectPermissionStateCreated, managedv1alpha1.RoleBindingCreated)
-			err = r.Client.Status().Update(ctx, instance)
-			if err != nil {
-				reqLogger.Error(err, "Failed to update condition in subjectpermission controller when successfully created all rolebindings")
-				return ctrl.Result{}, err
-			}
-			return ctrl.Result{}, nil
+	// remove RoleBindings that are no longer required, including those in namespaces that are now denied
+	if !skipRevoke && isDryRun(instance) {
+		for _, rb := range staleRoleBindings(instance, roleBindingList, desiredRoleBindings) {
+			controllerutil.RecordPlannedDeletion(&res.changes, rb.Namespace+"/"+rb.Name)
+		}
+	} else if !skipRevoke {
+		err = r.revokeRoleBindings(ctx, instance, roleBindingList, desiredRoleBindings, namespaces)
+		if err != nil {
+			reqLogger.Error(err, "Failed to revoke RoleBindings")
+			localmetrics.IncReconcileErrors("subjectpermission", "delete_rolebinding")
+			controllerutil.RecordEvent(r.Recorder, instance, nil, corev1.EventTypeWarning, controllerutil.EventReasonBindingFailed, controllerutil.EventActionRevoke, "Failed to revoke RoleBindings: %v", err)
+			res.failures = append(res.failures, fmt.Errorf("failed to revoke RoleBindings: %w", err))
 		}
+	}
+	return res, nil
+}
 
+// listRoles returns the Roles found on the cluster when a Permission of the SubjectPermission binds a Role, nil otherwise
+func (r *SubjectPermissionReconciler) listRoles(ctx context.Context, sp managedv1alpha1.SubjectPermissionObject) (map[types.NamespacedName]bool, error) {
+	if !slices.ContainsFunc(sp.GetSpec().Permissions, func(permission managedv1alpha1.Permission) bool {
+		return permission.Role().IsRole()
+	}) {
+		return nil, nil
+	}
+	roleList := &v1.RoleList{}
+	if err := r.List(ctx, roleList); err != nil {
+		return nil, fmt.Errorf("failed to list Roles: %w", err)
 	}
+	roles := make(map[types.NamespacedName]bool, len(roleList.Items))
+	for i := range roleList.Items {
+		roles[client.ObjectKeyFromObject(&roleList.Items[i])] = true
+	}
+	return roles, nil
+}
 
-	return ctrl.Result{}, nil
+// sortedNames returns a sorted copy of the names
+func sortedNames(names []string) []string {
+	sorted := slices.Clone(names)
+	slices.Sort(sorted)
+	return sorted
 }
 
-// NewClusterRoleBinding creates and returns ClusterRoleBinding
-func NewClusterRoleBinding(clusterRoleName, subjectName string, subjectKind string) *v1.ClusterRoleBinding {
+// uniqueSortedNames returns the names sorted without duplicates, nil when there are none
+func uniqueSortedNames(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	return slices.Compact(sortedNames(names))
+}
+
+// namesMessage returns the condition message listing the ClusterRoles or namespaces,
+// sorted so the message only changes when the names do
+func namesMessage(message string, names []string) string {
+	return fmt.Sprintf("%s: %s", message, strings.Join(sortedNames(names), ", "))
+}
+
+// revokeClusterRoleBindings deletes the ClusterRoleBindings created for the subject of the
+// SubjectPermission that are not part of the desired set.
+// This also migrates bindings created under legacy names: their replacement has already been
+// created under the current naming scheme, so the legacy binding is no longer desired.
+func (r *SubjectPermissionReconciler) revokeClusterRoleBindings(ctx context.Context, sp managedv1alpha1.SubjectPermissionObject, clusterRoleBindingList *v1.ClusterRoleBindingList, desired map[string]bool) error {
+	for _, crb := range staleClusterRoleBindings(sp, clusterRoleBindingList, desired) {
+		if err := r.Delete(ctx, crb); err != nil && !k8serr.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ClusterRoleBinding %s: %w", crb.Name, err)
+		}
+		if migrated := controllerutil.BindingName(sp, crb.RoleRef.Name, sp.GetSpec().SubjectKind, sp.GetSpec().SubjectNamespace, sp.GetSpec().SubjectName); desired[migrated] {
+			log.Info("ClusterRoleBinding migrated from legacy name", "name", crb.Name, "newName", migrated, "subject", sp.GetSpec().SubjectName)
+		} else {
+			log.Info("ClusterRoleBinding deleted successfully", "name", crb.Name, "subject", sp.GetSpec().SubjectName)
+			controllerutil.RecordEvent(r.Recorder, sp, nil, corev1.EventTypeNormal, controllerutil.EventReasonBindingRemoved, controllerutil.EventActionRevoke, "Deleted ClusterRoleBinding %s of ClusterRole %s", crb.Name, crb.RoleRef.Name)
+		}
+		localmetrics.IncResourcesDeleted("ClusterRoleBinding", sp.GetName())
+	}
+	return nil
+}
+
+// revokeRoleBindings deletes the RoleBindings created for the subject of the
+// SubjectPermission that are not part of the desired set.
+// Like revokeClusterRoleBindings, this removes legacy named RoleBindings once their replacement exists.
+// The Events of the deleted RoleBindings are emitted on the Namespaces found in namespaces as well
+func (r *SubjectPermissionReconciler) revokeRoleBindings(ctx context.Context, sp managedv1alpha1.SubjectPermissionObject, roleBindingList *v1.RoleBindingList, desired map[types.NamespacedName]bool, namespaces map[string]*corev1.Namespace) error {
+	for _, rb := range staleRoleBindings(sp, roleBindingList, desired) {
+		if err := r.Delete(ctx, rb); err != nil && !k8serr.IsNotFound(err) {
+			return fmt.Errorf("failed to delete RoleBinding %s in namespace %s: %w", rb.Name, rb.Namespace, err)
+		}
+		migrated := controllerutil.RoleBindingName(sp, managedv1alpha1.PermissionRoleRef{Kind: rb.RoleRef.Kind, Name: rb.RoleRef.Name}, sp.GetSpec().SubjectKind, sp.GetSpec().SubjectNamespace, sp.GetSpec().SubjectName)
+		if desired[types.NamespacedName{Namespace: rb.Namespace, Name: migrated}] {
+			log.Info("RoleBinding migrated from legacy name", "name", rb.Name, "newName", migrated, "namespace", rb.Namespace, "subject", sp.GetSpec().SubjectName)
+		} else {
+			log.Info("RoleBinding deleted successfully", "name", rb.Name, "namespace", rb.Namespace, "subject", sp.GetSpec().SubjectName)
+			controllerutil.RecordEvent(r.Recorder, sp, namespaces[rb.Namespace], corev1.EventTypeNormal, controllerutil.EventReasonBindingRemoved, controllerutil.EventActionRevoke, "Deleted RoleBinding %s in namespace %s of ClusterRole %s", rb.Name, rb.Namespace, rb.RoleRef.Name)
+		}
+		localmetrics.IncResourcesDeleted("RoleBinding", sp.GetName())
+	}
+	return nil
+}
+
+// revokeClusterRoles deletes the ClusterRoles created for the SubjectPermission that are not part of the desired set
+func (r *SubjectPermissionReconciler) revokeClusterRoles(ctx context.Context, sp managedv1alpha1.SubjectPermissionObject, clusterRoleList *v1.ClusterRoleList, desired map[string]bool) error {
+	for _, clusterRole := range staleClusterRoles(sp, clusterRoleList, desired) {
+		if err := r.Delete(ctx, clusterRole); err != nil && !k8serr.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ClusterRole %s: %w", clusterRole.Name, err)
+		}
+		log.Info("ClusterRole deleted successfully", "clusterRoleName", clusterRole.Name)
+		controllerutil.RecordEvent(r.Recorder, sp, nil, corev1.EventTypeNormal, controllerutil.EventReasonClusterRoleRemoved, controllerutil.EventActionRevoke, "Deleted ClusterRole %s", clusterRole.Name)
+		localmetrics.IncResourcesDeleted("ClusterRole", sp.GetName())
+	}
+	return nil
+}
+
+// staleClusterRoles returns the ClusterRoles created for the SubjectPermission that are not part of the desired set
+func staleClusterRoles(sp managedv1alpha1.SubjectPermissionObject, clusterRoleList *v1.ClusterRoleList, desired map[string]bool) []*v1.ClusterRole {
+	var stale []*v1.ClusterRole
+	for i := range clusterRoleList.Items {
+		clusterRole := &clusterRoleList.Items[i]
+		if !desired[clusterRole.Name] && controllerutil.IsManagedByOperator(clusterRole) && controllerutil.IsOwnedBy(clusterRole, sp) {
+			stale = append(stale, clusterRole)
+		}
+	}
+	return stale
+}
+
+// staleClusterRoleBindings returns the ClusterRoleBindings created for the subject of the SubjectPermission
+// that are not part of the desired set
+func staleClusterRoleBindings(sp managedv1alpha1.SubjectPermissionObject, clusterRoleBindingList *v1.ClusterRoleBindingList, desired map[string]bool) []*v1.ClusterRoleBinding {
+	var stale []*v1.ClusterRoleBinding
+	for i := range clusterRoleBindingList.Items {
+		crb := &clusterRoleBindingList.Items[i]
+		if !desired[crb.Name] && isManagedBinding(crb, crb.RoleRef, crb.Subjects, sp) {
+			stale = append(stale, crb)
+		}
+	}
+	return stale
+}
+
+// staleRoleBindings returns the RoleBindings created for the subject of the SubjectPermission
+// that are not part of the desired set
+func staleRoleBindings(sp managedv1alpha1.SubjectPermissionObject, roleBindingList *v1.RoleBindingList, desired map[types.NamespacedName]bool) []*v1.RoleBinding {
+	var stale []*v1.RoleBinding
+	for i := range roleBindingList.Items {
+		rb := &roleBindingList.Items[i]
+		if !desired[types.NamespacedName{Namespace: rb.Namespace, Name: rb.Name}] && isManagedBinding(rb, rb.RoleRef, rb.Subjects, sp) {
+			stale = append(stale, rb)
+		}
+	}
+	return stale
+}
+
+// cleanupBindings deletes every ClusterRoleBinding, RoleBinding and ClusterRole created for the SubjectPermission
+func (r *SubjectPermissionReconciler) cleanupBindings(ctx context.Context, sp managedv1alpha1.SubjectPermissionObject) error {
+	clusterRoleBindingList := &v1.ClusterRoleBindingList{}
+	if err := r.List(ctx, clusterRoleBindingList); err != nil {
+		return fmt.Errorf("failed to list ClusterRoleBindings: %w", err)
+	}
+	if err := r.revokeClusterRoleBindings(ctx, sp, clusterRoleBindingList, map[string]bool{}); err != nil {
+		return err
+	}
+
+	roleBindingList := &v1.RoleBindingList{}
+	if err := r.List(ctx, roleBindingList); err != nil {
+		return fmt.Errorf("failed to list RoleBindings: %w", err)
+	}
+	if err := r.revokeRoleBindings(ctx, sp, roleBindingList, map[types.NamespacedName]bool{}, nil); err != nil {
+		return err
+	}
+
+	// the ClusterRoles go last, once nothing created for the SubjectPermission binds them anymore
+	if len(sp.GetSpec().ClusterRoles) == 0 && len(sp.GetStatus().ClusterRoles) == 0 {
+		return nil
+	}
+	clusterRoleList := &v1.ClusterRoleList{}
+	if err := r.List(ctx, clusterRoleList); err != nil {
+		return fmt.Errorf("failed to list ClusterRoles: %w", err)
+	}
+	return r.revokeClusterRoles(ctx, sp, clusterRoleList, map[string]bool{})
+}
+
+// isManagedBinding checks if a binding was created by the operator for the SubjectPermission.
+// Bindings carrying ownership labels are matched on those, unlabeled bindings created by
+// earlier versions of the operator are matched on their name and subject.
+func isManagedBinding(obj metav1.Object, roleRef v1.RoleRef, subjects []v1.Subject, sp managedv1alpha1.SubjectPermissionObject) bool {
+	if controllerutil.IsManagedByOperator(obj) {
+		return controllerutil.IsOwnedBy(obj, sp)
+	}
+	// ClusterSubjectPermissions came after the ownership labels, unlabeled bindings are never theirs
+	if sp.GetNamespace() == "" {
+		return false
+	}
+	return isBindingForSubject(obj.GetName(), roleRef, subjects, sp)
+}
+
+// isBindingForSubject checks if a binding was generated by the operator for the subject of the
+// SubjectPermission under its legacy name "<clusterRoleName>-<subjectName>" and binds only that subject
+func isBindingForSubject(name string, roleRef v1.RoleRef, subjects []v1.Subject, sp managedv1alpha1.SubjectPermissionObject) bool {
+	if roleRef.Kind != "ClusterRole" || name != controllerutil.LegacyBindingName(roleRef.Name, sp.GetSpec().SubjectName) {
+		return false
+	}
+	if len(subjects) != 1 {
+		return false
+	}
+	return subjects[0].Kind == sp.GetSpec().SubjectKind && subjects[0].Name == sp.GetSpec().SubjectName
+}
+
+// NewClusterRoleBinding creates and returns ClusterRoleBinding, named after the SubjectPermission owner
+func NewClusterRoleBinding(owner managedv1alpha1.SubjectPermissionObject, clusterRoleName, subjectName, subjectNamespace, subjectKind string) *v1.ClusterRoleBinding {
 	return &v1.ClusterRoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: clusterRoleName + "-" + subjectName,
+			Name: controllerutil.BindingName(owner, clusterRoleName, subjectKind, subjectNamespace, subjectName),
 		},
 		Subjects: []v1.Subject{
-			{
-				Kind: subjectKind,
-				Name: subjectName,
-			},
+			controllerutil.NewSubject(subjectKind, subjectName, subjectNamespace),
 		},
 		RoleRef: v1.RoleRef{
 			Kind: "ClusterRole",
@@ -329,8 +961,8 @@ func NewClusterRoleBinding(clusterRoleName, subjectName string, subjectKind stri
 
 // PopulateCrClusterRoleNames to see if ClusterRoleName exists as a ClusterRole
 // returns list of ClusterRoleNames that do not exist
-func PopulateCrClusterRoleNames(subjectPermission *managedv1alpha1.SubjectPermission, clusterRoleList *v1.ClusterRoleList) []string {
-	crClusterRoleNames := subjectPermission.Spec.ClusterPermissions
+func PopulateCrClusterRoleNames(subjectPermission managedv1alpha1.SubjectPermissionObject, clusterRoleList *v1.ClusterRoleList) []string {
+	crClusterRoleNames := subjectPermission.GetSpec().ClusterPermissions
 
 	// items is list of clusterRole on k8s
 	onClusterItems := clusterRoleList.Items
@@ -366,60 +998,242 @@ func PopulateCrClusterRoleNames(subjectPermission *managedv1alpha1.SubjectPermis
 	return result
 }
 
-// validateSubjectPermission validates the SubjectPermission spec
-func (r *SubjectPermissionReconciler) validateSubjectPermission(sp *managedv1alpha1.SubjectPermission) error {
-	// Validate SubjectName
-	if strings.TrimSpace(sp.Spec.SubjectName) == "" {
-		return fmt.Errorf("subjectName cannot be empty")
-	}
+// ValidateSubjectPermission validates the SubjectPermission spec, returning every problem found as one error
+func ValidateSubjectPermission(sp managedv1alpha1.SubjectPermissionObject) error {
+	return validateSubjectPermissionSpec(sp.GetSpec(), field.NewPath("spec")).ToAggregate()
+}
+
+// validateSubjectPermissionSpec validates the SubjectPermission spec and returns every problem found.
+// It is shared by the reconciler and the validating webhook.
+func validateSubjectPermissionSpec(spec *managedv1alpha1.SubjectPermissionSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
 
-	// Validate SubjectKind
 	validKinds := []string{"User", "Group", "ServiceAccount"}
-	validKind := false
-	for _, kind := range validKinds {
-		if sp.Spec.SubjectKind == kind {
-			validKind = true
-			break
+
+	// The legacy single Subject is required unless the Subjects list is used
+	if spec.SubjectKind != "" || spec.SubjectName != "" || len(spec.Subjects) == 0 {
+		// Validate SubjectName
+		if strings.TrimSpace(spec.SubjectName) == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("subjectName"), "subjectName cannot be empty"))
+		}
+
+		// Validate SubjectKind
+		if !slices.Contains(validKinds, spec.SubjectKind) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subjectKind"), spec.SubjectKind, fmt.Sprintf("subjectKind must be one of: %s", strings.Join(validKinds, ", "))))
+		}
+
+		// Validate SubjectNamespace, ServiceAccounts cannot be bound without it
+		if spec.SubjectKind == v1.ServiceAccountKind && strings.TrimSpace(spec.SubjectNamespace) == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("subjectNamespace"), "subjectNamespace cannot be empty for a ServiceAccount"))
 		}
 	}
-	if !validKind {
-		return fmt.Errorf("subjectKind must be one of: %s, got: %s", strings.Join(validKinds, ", "), sp.Spec.SubjectKind)
+
+	// Validate Subjects
+	for i, subject := range spec.Subjects {
+		subjectPath := fldPath.Child("subjects").Index(i)
+		if strings.TrimSpace(subject.Name) == "" {
+			allErrs = append(allErrs, field.Required(subjectPath.Child("name"), "name cannot be empty"))
+		}
+		if !slices.Contains(validKinds, subject.Kind) {
+			allErrs = append(allErrs, field.Invalid(subjectPath.Child("kind"), subject.Kind, fmt.Sprintf("kind must be one of: %s", strings.Join(validKinds, ", "))))
+		}
+		if subject.Kind == v1.ServiceAccountKind && strings.TrimSpace(subject.Namespace) == "" {
+			allErrs = append(allErrs, field.Required(subjectPath.Child("namespace"), "namespace cannot be empty for a ServiceAccount"))
+		}
 	}
 
 	// Validate ClusterPermissions
-	for _, clusterRoleName := range sp.Spec.ClusterPermissions {
+	for i, clusterRoleName := range spec.ClusterPermissions {
 		if strings.TrimSpace(clusterRoleName) == "" {
-			return fmt.Errorf("clusterRoleName cannot be empty")
+			allErrs = append(allErrs, field.Required(fldPath.Child("clusterPermissions").Index(i), "clusterRoleName cannot be empty"))
+		}
+	}
+
+	// Validate ClusterRoles
+	clusterRoleNames := map[string]bool{}
+	for i, definition := range spec.ClusterRoles {
+		namePath := fldPath.Child("clusterRoles").Index(i).Child("name")
+		switch {
+		case strings.TrimSpace(definition.Name) == "":
+			allErrs = append(allErrs, field.Required(namePath, "name cannot be empty"))
+		case clusterRoleNames[definition.Name]:
+			allErrs = append(allErrs, field.Duplicate(namePath, definition.Name))
+		default:
+			for _, msg := range path.IsValidPathSegmentName(definition.Name) {
+				allErrs = append(allErrs, field.Invalid(namePath, definition.Name, msg))
+			}
+		}
+		clusterRoleNames[definition.Name] = true
+		if definition.AggregationRule != nil {
+			selectorsPath := fldPath.Child("clusterRoles").Index(i).Child("aggregationRule", "clusterRoleSelectors")
+			for j := range definition.AggregationRule.ClusterRoleSelectors {
+				allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&definition.AggregationRule.ClusterRoleSelectors[j], metav1validation.LabelSelectorValidationOptions{}, selectorsPath.Index(j))...)
+			}
 		}
 	}
 
+	// Validate the expiration
+	if spec.ExpiresAt != nil && spec.Duration != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("duration"), "duration cannot be set together with expiresAt"))
+	}
+	if spec.Duration != nil && spec.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("duration"), spec.Duration.Duration.String(), "duration must be positive"))
+	}
+
 	// Validate Permissions regex patterns
-	for i, permission := range sp.Spec.Permissions {
-		if strings.TrimSpace(permission.ClusterRoleName) == "" {
-			return fmt.Errorf("permission[%d].clusterRoleName cannot be empty", i)
+	for i, permission := range spec.Permissions {
+		permPath := fldPath.Child("permissions").Index(i)
+		switch {
+		case permission.RoleRef != nil && permission.ClusterRoleName != "":
+			allErrs = append(allErrs, field.Forbidden(permPath.Child("roleRef"), "roleRef cannot be set together with clusterRoleName"))
+		case permission.RoleRef != nil:
+			roleRefPath := permPath.Child("roleRef")
+			if permission.RoleRef.Kind != managedv1alpha1.RoleKindRole && permission.RoleRef.Kind != managedv1alpha1.RoleKindClusterRole {
+				allErrs = append(allErrs, field.NotSupported(roleRefPath.Child("kind"), permission.RoleRef.Kind, []string{managedv1alpha1.RoleKindRole, managedv1alpha1.RoleKindClusterRole}))
+			}
+			if strings.TrimSpace(permission.RoleRef.Name) == "" {
+				allErrs = append(allErrs, field.Required(roleRefPath.Child("name"), "name cannot be empty"))
+			}
+		case strings.TrimSpace(permission.ClusterRoleName) == "":
+			allErrs = append(allErrs, field.Required(permPath.Child("clusterRoleName"), "clusterRoleName cannot be empty unless roleRef is set"))
 		}
 
 		// Validate NamespacesAllowedRegex
 		if permission.NamespacesAllowedRegex != "" {
 			if _, err := regexp.Compile(permission.NamespacesAllowedRegex); err != nil {
-				return fmt.Errorf("invalid namespacesAllowedRegex in permission[%d]: %w", i, err)
+				allErrs = append(allErrs, field.Invalid(permPath.Child("namespacesAllowedRegex"), permission.NamespacesAllowedRegex, fmt.Sprintf("invalid namespacesAllowedRegex: %v", err)))
 			}
 		}
 
 		// Validate NamespacesDeniedRegex
 		if permission.NamespacesDeniedRegex != "" {
 			if _, err := regexp.Compile(permission.NamespacesDeniedRegex); err != nil {
-				return fmt.Errorf("invalid namespacesDeniedRegex in permission[%d]: %w", i, err)
+				allErrs = append(allErrs, field.Invalid(permPath.Child("namespacesDeniedRegex"), permission.NamespacesDeniedRegex, fmt.Sprintf("invalid namespacesDeniedRegex: %v", err)))
 			}
 		}
+
+		// Validate NamespaceSelector and NamespaceDenySelector
+		selectorOpts := metav1validation.LabelSelectorValidationOptions{}
+		if permission.NamespaceSelector != nil {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(permission.NamespaceSelector, selectorOpts, permPath.Child("namespaceSelector"))...)
+		}
+		if permission.NamespaceDenySelector != nil {
+			allErrs = append(allErrs, metav1validation.ValidateLabelSelector(permission.NamespaceDenySelector, selectorOpts, permPath.Child("namespaceDenySelector"))...)
+		}
 	}
 
-	return nil
+	return allErrs
 }
 
+// SubjectPermissionForBinding maps a binding created by the operator to the SubjectPermission owning it
+func SubjectPermissionForBinding(ctx context.Context, obj client.Object) []reconcile.Request {
+	owner, ok := controllerutil.OwnerOf(obj)
+	if !ok || owner.Namespace == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: owner}}
+}
+
+// watchesOwnerOf checks if the SubjectPermission owning the binding is in a watched namespace
+func (r *SubjectPermissionReconciler) watchesOwnerOf(obj client.Object) bool {
+	if len(r.WatchNamespaces) == 0 {
+		return true
+	}
+	owner, ok := controllerutil.OwnerOf(obj)
+	return ok && slices.Contains(r.WatchNamespaces, owner.Namespace)
+}
+
+// allSubjectPermissions maps a change of the operator configuration to every SubjectPermission
+func (r *SubjectPermissionReconciler) allSubjectPermissions(ctx context.Context, obj client.Object) []reconcile.Request {
+	subjectPermissionList := &managedv1alpha1.SubjectPermissionList{}
+	if err := r.List(ctx, subjectPermissionList); err != nil {
+		log.Error(err, "Failed to list SubjectPermissions after a configuration change")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(subjectPermissionList.Items))
+	for i := range subjectPermissionList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&subjectPermissionList.Items[i])})
+	}
+	return requests
+}
+
+// SubjectPermissionsForClusterRole maps a ClusterRole to the SubjectPermissions granting it, found with the index on
+// ClusterRoleReferenceField, and to the SubjectPermission it was created for
+func (r *SubjectPermissionReconciler) SubjectPermissionsForClusterRole(ctx context.Context, obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	if r.watchesOwnerOf(obj) {
+		requests = SubjectPermissionForBinding(ctx, obj)
+	}
+	subjectPermissionList := &managedv1alpha1.SubjectPermissionList{}
+	if err := r.List(ctx, subjectPermissionList, client.MatchingFields{controllerutil.ClusterRoleReferenceField: obj.GetName()}); err != nil {
+		log.Error(err, "Failed to list the SubjectPermissions of a ClusterRole", "clusterRoleName", obj.GetName())
+		return requests
+	}
+	for i := range subjectPermissionList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&subjectPermissionList.Items[i])})
+	}
+	return requests
+}
+
+// SubjectPermissionsForRole maps a Role to the SubjectPermissions with a Permission binding a Role of its name, found
+// with the index on RoleReferenceField
+func (r *SubjectPermissionReconciler) SubjectPermissionsForRole(ctx context.Context, obj client.Object) []reconcile.Request {
+	subjectPermissionList := &managedv1alpha1.SubjectPermissionList{}
+	if err := r.List(ctx, subjectPermissionList, client.MatchingFields{controllerutil.RoleReferenceField: obj.GetName()}); err != nil {
+		log.Error(err, "Failed to list the SubjectPermissions of a Role", "roleName", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(subjectPermissionList.Items))
+	for i := range subjectPermissionList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&subjectPermissionList.Items[i])})
+	}
+	return requests
+}
+
+// roleExistence only lets through the creation and deletion of ClusterRoles and Roles, which are the changes that make
+// a SubjectPermission grant or stop granting them
+var roleExistence = builder.WithPredicates(predicate.Funcs{
+	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+})
+
+// clusterRoleChanges lets through the creation and deletion of ClusterRoles like roleExistence, and the edits of the
+// ClusterRoles created by the operator, which are restored
+var clusterRoleChanges = builder.WithPredicates(predicate.Funcs{
+	UpdateFunc:  func(e event.UpdateEvent) bool { return controllerutil.IsManagedByOperator(e.ObjectNew) },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+})
+
 // SetupWithManager sets up the controller with the Manager.
+// Bindings created by the operator are watched as well, so edits and deletions are reverted,
+// and every SubjectPermission is reconciled again when the operator configuration changes.
+// SubjectPermissions are indexed on the ClusterRoles and Roles they grant, so the creation or deletion of a role
+// only reconciles the SubjectPermissions referencing it. The ClusterRoles created for a SubjectPermission are restored
+// like its bindings.
 func (r *SubjectPermissionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &managedv1alpha1.SubjectPermission{}, controllerutil.ClusterRoleReferenceField, controllerutil.IndexByClusterRole)
+	if err != nil {
+		return fmt.Errorf("failed to index SubjectPermissions by ClusterRole: %w", err)
+	}
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &managedv1alpha1.SubjectPermission{}, controllerutil.RoleReferenceField, controllerutil.IndexByRole)
+	if err != nil {
+		return fmt.Errorf("failed to index SubjectPermissions by Role: %w", err)
+	}
 
-	return ctrl.NewControllerManagedBy(mgr).For(&managedv1alpha1.SubjectPermission{}).Complete(r)
+	// the bindings of SubjectPermissions outside of the watched namespaces belong to another instance of the operator
+	managedBindings := builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return controllerutil.IsManagedByOperator(obj) && r.watchesOwnerOf(obj)
+	}))
 
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&managedv1alpha1.SubjectPermission{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.Config.Get().MaxConcurrentReconciles}).
+		Watches(&v1.ClusterRoleBinding{}, handler.EnqueueRequestsFromMapFunc(SubjectPermissionForBinding), managedBindings).
+		Watches(&v1.RoleBinding{}, handler.EnqueueRequestsFromMapFunc(SubjectPermissionForBinding), managedBindings).
+		Watches(&v1.ClusterRole{}, handler.EnqueueRequestsFromMapFunc(r.SubjectPermissionsForClusterRole), clusterRoleChanges).
+		Watches(&v1.Role{}, handler.EnqueueRequestsFromMapFunc(r.SubjectPermissionsForRole), roleExistence)
+	if r.Config != nil {
+		b = b.WatchesRawSource(source.Channel(r.Config.Subscribe(), handler.EnqueueRequestsFromMapFunc(r.allSubjectPermissions)))
+	}
+	return b.Complete(r)
 }
@@ -11,8 +11,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -20,6 +23,8 @@ import (
 	"github.com/openshift/rbac-permissions-operator/api/v1alpha1"
 	"github.com/openshift/rbac-permissions-operator/controllers/subjectpermission"
 	testconst "github.com/openshift/rbac-permissions-operator/pkg/const/test"
+	controllerutil "github.com/openshift/rbac-permissions-operator/pkg/controllerutils"
+	"github.com/openshift/rbac-permissions-operator/pkg/operatorconfig"
 	clientmocks "github.com/openshift/rbac-permissions-operator/pkg/util/test/generated/mocks/client"
 )
 
@@ -61,28 +66,62 @@ var _ = Describe("SubjectPermission Controller", func() {
 
 		When("ClusterRoleName does not exist as a ClusterRole", func() {
 			It("Updates status condition that the ClusterRole for ClusterPermission does not exist", func() {
+				recorder := events.NewFakeRecorder(10)
+				subjectPermissionReconciler.Recorder = recorder
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
 					mockClient.EXPECT().Status().Return(mockStatusWriter),
 					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
 						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
-							Expect(sp.Status.Conditions[1].Message).To(Equal("ClusterRole for ClusterPermission does not exist"))
-							Expect(sp.Status.Conditions[1].ClusterRoleNames).To(ContainElement(ContainSubstring("exampleClusterRoleName")))
-							Expect(sp.Status.Conditions[1].ClusterRoleNames).To(ContainElement(ContainSubstring("exampleClusterRoleNameTwo")))
-							Expect(sp.Status.Conditions[1].Status).To(Equal(true))
-							Expect(sp.Status.Conditions[1].State).To(Equal(v1alpha1.SubjectPermissionStateFailed))
-							Expect(sp.Status.Conditions[1].Type).To(Equal(v1alpha1.ClusterRoleBindingCreated))
+							degraded := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionDegraded)
+							Expect(degraded).ToNot(BeNil())
+							Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+							Expect(degraded.Reason).To(Equal(v1alpha1.ReasonClusterRoleNotFound))
+							Expect(degraded.Message).To(Equal("ClusterRole for ClusterPermission does not exist: exampleClusterRoleName, exampleClusterRoleNameTwo; " +
+								"Role for Permission does not exist: exampleClusterRoleName, testClusterRoleName"))
+							Expect(meta.IsStatusConditionFalse(sp.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
 							return nil
 						}),
 				)
 				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).ToNot(HaveOccurred())
+				Expect(recorder.Events).To(HaveLen(4))
+				Expect(<-recorder.Events).To(Equal("Warning ClusterRoleMissing ClusterRole exampleClusterRoleName for ClusterPermission does not exist"))
+				Expect(<-recorder.Events).To(Equal("Warning ClusterRoleMissing ClusterRole exampleClusterRoleNameTwo for ClusterPermission does not exist"))
+				Expect(<-recorder.Events).To(Equal("Warning ClusterRoleMissing ClusterRole exampleClusterRoleName for Permission does not exist"))
+				Expect(<-recorder.Events).To(Equal("Warning ClusterRoleMissing ClusterRole testClusterRoleName for Permission does not exist"))
+			})
+			It("Only emits the events of the ClusterRoles the previous reconcile did not report", func() {
+				recorder := events.NewFakeRecorder(10)
+				subjectPermissionReconciler.Recorder = recorder
+				reported := *testSubjectPermission.DeepCopy()
+				reported.Status.MissingClusterRoles = []string{"exampleClusterRoleNameTwo", "testClusterRoleName"}
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, reported),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							Expect(sp.Status.MissingClusterRoles).To(Equal([]string{"exampleClusterRoleName", "exampleClusterRoleNameTwo", "testClusterRoleName"}))
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(recorder.Events).To(HaveLen(2))
+				Expect(<-recorder.Events).To(Equal("Warning ClusterRoleMissing ClusterRole exampleClusterRoleName for ClusterPermission does not exist"))
+				Expect(<-recorder.Events).To(Equal("Warning ClusterRoleMissing ClusterRole exampleClusterRoleName for Permission does not exist"))
 			})
 		})
 
-		When("ClusterRoleBindings are successfully created", func() {
+		When("ClusterRoleBindings and RoleBindings are both required", func() {
 			BeforeEach(func() {
 				testClusterRoleList = rbacv1.ClusterRoleList{
 					Items: []rbacv1.ClusterRole{
@@ -96,151 +135,1149 @@ var _ = Describe("SubjectPermission Controller", func() {
 								Name: "exampleClusterRoleNameTwo",
 							},
 						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "testClusterRoleName",
+							},
+						},
+					},
+				}
+				testNamespaceList = &corev1.NamespaceList{
+					Items: []corev1.Namespace{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "default",
+							},
+						},
+					},
+				}
+			})
+			It("Creates them in a single reconcile", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).Return(nil),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
+							Expect(meta.IsStatusConditionTrue(sp.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
+							Expect(sp.Status.ClusterRoleBindings).To(ConsistOf(
+								controllerutil.BindingName(sp, "exampleClusterRoleName", sp.Spec.SubjectKind, "", sp.Spec.SubjectName),
+								controllerutil.BindingName(sp, "exampleClusterRoleNameTwo", sp.Spec.SubjectKind, "", sp.Spec.SubjectName),
+							))
+							Expect(sp.Status.Permissions).To(ConsistOf(
+								v1alpha1.PermissionStatus{ClusterRoleName: "exampleClusterRoleName", RoleBindings: 1},
+								v1alpha1.PermissionStatus{ClusterRoleName: "testClusterRoleName"},
+							))
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("There are no ClusterPermissions and allowed namespaces for RoleBinding creation", func() {
+			BeforeEach(func() {
+				testClusterRoleList = rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "exampleClusterRoleName",
+							},
+						},
+					},
+				}
+				testSubjectPermission = v1alpha1.SubjectPermission{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testSubjectPermission",
+						Namespace: "rbac-permissions-operator",
+					},
+					Spec: v1alpha1.SubjectPermissionSpec{
+						SubjectName:        "exampleSubjectName",
+						SubjectKind:        "exampleSubjectKind",
+						ClusterPermissions: []string{},
+						Permissions:        []v1alpha1.Permission{},
+					},
+					Status: v1alpha1.SubjectPermissionStatus{
+						Conditions: []metav1.Condition{},
+					},
+				}
+				testNamespaceList = &corev1.NamespaceList{
+					Items: []corev1.Namespace{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "test",
+							},
+						},
+					},
+				}
+			})
+			It("Should successfully reconcile", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("The SubjectPermission is already Ready for its generation", func() {
+			BeforeEach(func() {
+				testSubjectPermission.Generation = 2
+				testSubjectPermission.Spec.ClusterPermissions = nil
+				testSubjectPermission.Spec.Permissions = nil
+			})
+			It("Does not update the status again", func() {
+				var reconciled v1alpha1.SubjectPermission
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
+							Expect(sp.Status.ObservedGeneration).To(Equal(int64(2)))
+							sp.DeepCopyInto(&reconciled)
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, reconciled),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+				)
+				_, err = subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("ClusterRoleName does not exist as a Role", func() {
+			BeforeEach(func() {
+				testClusterRoleList = rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "exampleClusterRoleName",
+							},
+						},
+					},
+				}
+				testSubjectPermission = v1alpha1.SubjectPermission{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testSubjectPermission",
+						Namespace: "rbac-permissions-operator",
+					},
+					Spec: v1alpha1.SubjectPermissionSpec{
+						SubjectName:        "exampleSubjectName",
+						SubjectKind:        "exampleSubjectKind",
+						ClusterPermissions: []string{},
+						Permissions: []v1alpha1.Permission{
+							{
+								ClusterRoleName:        "exampleClusterRoleName",
+								NamespacesAllowedRegex: testconst.TestDefaultAllowedList,
+								NamespacesDeniedRegex:  testconst.TestEmptyDeniedList,
+							},
+							{
+								ClusterRoleName:        "testClusterRoleName",
+								NamespacesAllowedRegex: "test-namespace",
+								NamespacesDeniedRegex:  "",
+							},
+						},
+					},
+					Status: v1alpha1.SubjectPermissionStatus{
+						Conditions: []metav1.Condition{},
+					},
+				}
+				testNamespaceList = &corev1.NamespaceList{
+					Items: []corev1.Namespace{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "test",
+							},
+						},
+					},
+				}
+			})
+			It("Should reconcile successfully if any ClusterRoleName does not exist as a Role", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
+							degraded := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionDegraded)
+							Expect(degraded).ToNot(BeNil())
+							Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+							Expect(degraded.Reason).To(Equal(v1alpha1.ReasonClusterRoleNotFound))
+							Expect(degraded.Message).To(Equal("Role for Permission does not exist: testClusterRoleName"))
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+		})
+
+		When("RoleBindings are created in allowed namespaces", func() {
+			BeforeEach(func() {
+				testClusterRoleList = rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "exampleClusterRoleName",
+							},
+						},
+					},
+				}
+				testSubjectPermission = v1alpha1.SubjectPermission{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "testSubjectPermission",
+						Namespace: "rbac-permissions-operator",
+					},
+					Spec: v1alpha1.SubjectPermissionSpec{
+						SubjectName:        "exampleSubjectName",
+						SubjectKind:        "exampleSubjectKind",
+						ClusterPermissions: []string{},
+						Permissions: []v1alpha1.Permission{
+							{
+								ClusterRoleName:        "exampleClusterRoleName",
+								NamespacesAllowedRegex: testconst.TestDefaultAllowedList,
+								NamespacesDeniedRegex:  testconst.TestEmptyDeniedList,
+							},
+						},
+					},
+					Status: v1alpha1.SubjectPermissionStatus{
+						Conditions: []metav1.Condition{},
+					},
+				}
+				testNamespaceList = &corev1.NamespaceList{
+					Items: []corev1.Namespace{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "test",
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "default",
+							},
+						},
+					},
+				}
+			})
+			It("Should mark the SubjectPermission Ready once the RoleBindings are created", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).SetArg(1, *testconst.TestRoleBinding),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
+							ready := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionReady)
+							Expect(ready).ToNot(BeNil())
+							Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+							Expect(ready.Reason).To(Equal(v1alpha1.ReasonReconciled))
+							Expect(ready.ObservedGeneration).To(Equal(sp.Generation))
+							Expect(meta.IsStatusConditionFalse(sp.Status.Conditions, v1alpha1.ConditionDegraded)).To(BeTrue())
+							Expect(meta.IsStatusConditionFalse(sp.Status.Conditions, v1alpha1.ConditionProgressing)).To(BeTrue())
+							Expect(sp.Status.ObservedGeneration).To(Equal(sp.Generation))
+							Expect(sp.Status.Permissions).To(ConsistOf(v1alpha1.PermissionStatus{ClusterRoleName: "exampleClusterRoleName", RoleBindings: 1}))
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("A ClusterPermission is removed from the SubjectPermission", func() {
+			BeforeEach(func() {
+				testClusterRoleList = rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "exampleClusterRoleName",
+							},
+						},
 					},
 				}
+				testSubjectPermission.Spec.ClusterPermissions = []string{"exampleClusterRoleName"}
+				testClusterRoleBindingList = rbacv1.ClusterRoleBindingList{
+					Items: []rbacv1.ClusterRoleBinding{
+						testconst.TestClusterRoleBinding,
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "otherClusterRoleName-exampleSubjectName",
+								Labels: map[string]string{
+									controllerutil.ManagedByLabel:                  "rbac-permissions-operator",
+									controllerutil.SubjectPermissionNamespaceLabel: "rbac-permissions-operator",
+									controllerutil.SubjectPermissionNameLabel:      "otherSubjectPermission",
+								},
+							},
+							Subjects: []rbacv1.Subject{
+								{
+									Kind: "exampleSubjectKind",
+									Name: "exampleSubjectName",
+								},
+							},
+							RoleRef: rbacv1.RoleRef{
+								Kind: "ClusterRole",
+								Name: "otherClusterRoleName",
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "removedClusterRoleName-exampleSubjectName",
+							},
+							Subjects: []rbacv1.Subject{
+								{
+									Kind: "exampleSubjectKind",
+									Name: "exampleSubjectName",
+								},
+							},
+							RoleRef: rbacv1.RoleRef{
+								Kind: "ClusterRole",
+								Name: "removedClusterRoleName",
+							},
+						},
+					},
+				}
+			})
+			It("Deletes the ClusterRoleBinding that is no longer required", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, crb *rbacv1.ClusterRoleBinding, co ...client.CreateOption) error {
+							Expect(crb.Labels).To(HaveKeyWithValue(controllerutil.SubjectPermissionNameLabel, testSubjectPermission.Name))
+							Expect(crb.Labels).To(HaveKeyWithValue(controllerutil.SubjectPermissionNamespaceLabel, testSubjectPermission.Namespace))
+							return nil
+						}),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, crb *rbacv1.ClusterRoleBinding, do ...client.DeleteOption) error {
+							Expect(crb.Name).To(Equal("removedClusterRoleName-exampleSubjectName"))
+							return nil
+						}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("A ClusterRoleBinding exists under its legacy name", func() {
+			BeforeEach(func() {
+				testClusterRoleList = rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "exampleClusterRoleName",
+							},
+						},
+					},
+				}
+				testSubjectPermission.Spec.ClusterPermissions = []string{"exampleClusterRoleName"}
+				testClusterRoleBindingList = rbacv1.ClusterRoleBindingList{
+					Items: []rbacv1.ClusterRoleBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "exampleClusterRoleName-exampleSubjectName",
+							},
+							Subjects: []rbacv1.Subject{
+								{
+									Kind: "exampleSubjectKind",
+									Name: "exampleSubjectName",
+								},
+							},
+							RoleRef: rbacv1.RoleRef{
+								Kind: "ClusterRole",
+								Name: "exampleClusterRoleName",
+							},
+						},
+					},
+				}
+			})
+			It("Creates the binding under the new name before deleting the legacy one", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, crb *rbacv1.ClusterRoleBinding, co ...client.CreateOption) error {
+							Expect(crb.Name).To(Equal(controllerutil.BindingName(&testSubjectPermission, "exampleClusterRoleName", "exampleSubjectKind", "", "exampleSubjectName")))
+							return nil
+						}),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, crb *rbacv1.ClusterRoleBinding, do ...client.DeleteOption) error {
+							Expect(crb.Name).To(Equal("exampleClusterRoleName-exampleSubjectName"))
+							return nil
+						}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("A ClusterRoleBinding created by the operator was edited", func() {
+			BeforeEach(func() {
+				testClusterRoleList = rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "exampleClusterRoleName",
+							},
+						},
+					},
+				}
+				testSubjectPermission.Spec.ClusterPermissions = []string{"exampleClusterRoleName"}
+				testSubjectPermission.Spec.Permissions = nil
+				editedCRB := subjectpermission.NewClusterRoleBinding(&testSubjectPermission, "exampleClusterRoleName", "exampleSubjectName", "", "exampleSubjectKind")
+				controllerutil.SetOwnershipMetadata(editedCRB, &testSubjectPermission, "exampleClusterRoleName")
+				editedCRB.Subjects = append(editedCRB.Subjects, rbacv1.Subject{Kind: "User", Name: "intruder"})
+				testClusterRoleBindingList = rbacv1.ClusterRoleBindingList{
+					Items: []rbacv1.ClusterRoleBinding{*editedCRB},
+				}
+			})
+			It("Patches the subjects back to the desired state", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, crb *rbacv1.ClusterRoleBinding, patch client.Patch, po ...client.PatchOption) error {
+							Expect(crb.Subjects).To(HaveLen(1))
+							Expect(crb.Subjects[0].Name).To(Equal("exampleSubjectName"))
+							return nil
+						}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("A binding is mapped back to its SubjectPermission", func() {
+			It("Enqueues the owner of a managed binding", func() {
+				crb := subjectpermission.NewClusterRoleBinding(&testSubjectPermission, "exampleClusterRoleName", "exampleSubjectName", "", "exampleSubjectKind")
+				controllerutil.SetOwnershipMetadata(crb, &testSubjectPermission, "exampleClusterRoleName")
+				requests := subjectpermission.SubjectPermissionForBinding(testconst.Context, crb)
+				Expect(requests).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testSubjectPermission.Namespace, Name: testSubjectPermission.Name}}))
+			})
+
+			It("Ignores bindings not created by the operator", func() {
+				crb := testconst.TestClusterRoleBinding
+				Expect(subjectpermission.SubjectPermissionForBinding(testconst.Context, &crb)).To(BeEmpty())
+			})
+
+			It("Enqueues the ClusterSubjectPermission owning a binding with the cluster controller only", func() {
+				csp := &v1alpha1.ClusterSubjectPermission{ObjectMeta: metav1.ObjectMeta{Name: "dedicated-admins"}}
+				crb := subjectpermission.NewClusterRoleBinding(&testSubjectPermission, "exampleClusterRoleName", "exampleSubjectName", "", "exampleSubjectKind")
+				controllerutil.SetOwnershipMetadata(crb, csp, "exampleClusterRoleName")
+				Expect(subjectpermission.SubjectPermissionForBinding(testconst.Context, crb)).To(BeEmpty())
+				Expect(subjectpermission.ClusterSubjectPermissionForBinding(testconst.Context, crb)).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Name: "dedicated-admins"}}))
+
+				controllerutil.SetOwnershipMetadata(crb, &testSubjectPermission, "exampleClusterRoleName")
+				Expect(subjectpermission.ClusterSubjectPermissionForBinding(testconst.Context, crb)).To(BeEmpty())
+			})
+		})
+
+		When("A ClusterRole is created or deleted", func() {
+			It("Enqueues the SubjectPermissions granting it from the index", func() {
+				clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "exampleClusterRoleName"}}
+				mockClient.EXPECT().List(gomock.Any(), gomock.AssignableToTypeOf(&v1alpha1.SubjectPermissionList{}), client.MatchingFields{controllerutil.ClusterRoleReferenceField: "exampleClusterRoleName"}).
+					Times(1).SetArg(1, v1alpha1.SubjectPermissionList{Items: []v1alpha1.SubjectPermission{testSubjectPermission}})
+				requests := subjectPermissionReconciler.SubjectPermissionsForClusterRole(testconst.Context, clusterRole)
+				Expect(requests).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testSubjectPermission.Namespace, Name: testSubjectPermission.Name}}))
+			})
+
+			It("Enqueues the ClusterSubjectPermissions granting it with the cluster controller", func() {
+				clusterSubjectPermissionReconciler := subjectpermission.ClusterSubjectPermissionReconciler{SubjectPermissionReconciler: subjectPermissionReconciler}
+				clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "exampleClusterRoleName"}}
+				mockClient.EXPECT().List(gomock.Any(), gomock.AssignableToTypeOf(&v1alpha1.ClusterSubjectPermissionList{}), client.MatchingFields{controllerutil.ClusterRoleReferenceField: "exampleClusterRoleName"}).
+					Times(1).SetArg(1, v1alpha1.ClusterSubjectPermissionList{Items: []v1alpha1.ClusterSubjectPermission{{ObjectMeta: metav1.ObjectMeta{Name: "dedicated-admins"}}}})
+				requests := clusterSubjectPermissionReconciler.ClusterSubjectPermissionsForClusterRole(testconst.Context, clusterRole)
+				Expect(requests).To(ConsistOf(reconcile.Request{NamespacedName: types.NamespacedName{Name: "dedicated-admins"}}))
+			})
+
+			It("Enqueues nothing when the SubjectPermissions cannot be listed", func() {
+				clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "exampleClusterRoleName"}}
+				mockClient.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(fmt.Errorf("fake error"))
+				Expect(subjectPermissionReconciler.SubjectPermissionsForClusterRole(testconst.Context, clusterRole)).To(BeEmpty())
+			})
+		})
+
+		When("A ClusterSubjectPermission is reconciled", func() {
+			It("Applies its bindings with the shared reconcile code", func() {
+				clusterSubjectPermissionReconciler := subjectpermission.ClusterSubjectPermissionReconciler{SubjectPermissionReconciler: subjectPermissionReconciler}
+				csp := v1alpha1.ClusterSubjectPermission{
+					ObjectMeta: metav1.ObjectMeta{Name: "dedicated-admins"},
+					Spec: v1alpha1.SubjectPermissionSpec{
+						SubjectKind:        "Group",
+						SubjectName:        "dedicated-admins",
+						ClusterPermissions: []string{"exampleClusterRoleName"},
+					},
+				}
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), types.NamespacedName{Name: "dedicated-admins"}, gomock.AssignableToTypeOf(&v1alpha1.ClusterSubjectPermission{})).Times(1).SetArg(2, csp),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleList{Items: []rbacv1.ClusterRole{{ObjectMeta: metav1.ObjectMeta{Name: "exampleClusterRoleName"}}}}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, crb *rbacv1.ClusterRoleBinding, co ...client.CreateOption) error {
+							owner, ok := controllerutil.OwnerOf(crb)
+							Expect(ok).To(BeTrue())
+							Expect(owner).To(Equal(types.NamespacedName{Name: "dedicated-admins"}))
+							return nil
+						}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, csp *v1alpha1.ClusterSubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							Expect(meta.IsStatusConditionTrue(csp.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
+							Expect(csp.Status.ClusterRoleBindings).To(HaveLen(1))
+							return nil
+						}),
+				)
+				_, err := clusterSubjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: types.NamespacedName{Name: "dedicated-admins"}})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("The SubjectPermission lists several subjects", func() {
+			BeforeEach(func() {
+				testClusterRoleList = rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "exampleClusterRoleName",
+							},
+						},
+					},
+				}
+				testSubjectPermission.Spec.ClusterPermissions = []string{"exampleClusterRoleName"}
+				testSubjectPermission.Spec.Permissions = nil
+				testSubjectPermission.Spec.Subjects = []rbacv1.Subject{
+					{Kind: "Group", Name: "exampleGroupName"},
+					{Kind: "exampleSubjectKind", Name: "exampleSubjectName"},
+				}
+				testClusterRoleBindingList = rbacv1.ClusterRoleBindingList{}
+			})
+			It("Creates one ClusterRoleBinding per subject and reports each subject in status", func() {
+				var created []string
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).DoAndReturn(
+						func(ctx context.Context, crb *rbacv1.ClusterRoleBinding, co ...client.CreateOption) error {
+							Expect(crb.Subjects).To(HaveLen(1))
+							created = append(created, crb.Subjects[0].Name)
+							return nil
+						}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							Expect(sp.Status.Subjects).To(HaveLen(2))
+							Expect(sp.Status.Subjects[0].Name).To(Equal("exampleSubjectName"))
+							Expect(sp.Status.Subjects[0].ClusterRoleBindings).To(Equal(1))
+							Expect(sp.Status.Subjects[1].Name).To(Equal("exampleGroupName"))
+							Expect(sp.Status.Subjects[1].ClusterRoleBindings).To(Equal(1))
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(ConsistOf("exampleSubjectName", "exampleGroupName"))
+			})
+		})
+
+		When("The SubjectPermission is in DryRun mode", func() {
+			BeforeEach(func() {
+				testClusterRoleList = rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "exampleClusterRoleName",
+							},
+						},
+					},
+				}
+				testSubjectPermission.Spec.Mode = v1alpha1.ModeDryRun
+				testSubjectPermission.Spec.ClusterPermissions = []string{"exampleClusterRoleName"}
+				testSubjectPermission.Spec.Permissions = []v1alpha1.Permission{
+					{
+						ClusterRoleName:        "exampleClusterRoleName",
+						NamespacesAllowedRegex: testconst.TestDefaultAllowedList,
+					},
+				}
+				testClusterRoleBindingList = rbacv1.ClusterRoleBindingList{}
+			})
+			It("Reports the plan without applying any binding", func() {
+				staleRoleBinding := controllerutil.NewRoleBindingForClusterRole(&testSubjectPermission, "exampleClusterRoleName", "exampleSubjectName", "", "exampleSubjectKind", "test")
+				controllerutil.SetOwnershipMetadata(staleRoleBinding, &testSubjectPermission, "exampleClusterRoleName")
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{Items: []corev1.Namespace{
+						{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "test"}},
+					}}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{Items: []rbacv1.RoleBinding{*staleRoleBinding}}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							Expect(meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionReady).Reason).To(Equal(v1alpha1.ReasonDryRun))
+							Expect(sp.Status.Plan).ToNot(BeNil())
+							Expect(sp.Status.Plan.ClusterRoleBindings).To(Equal(v1alpha1.BindingChanges{
+								Create:   1,
+								ToCreate: []string{controllerutil.BindingName(&testSubjectPermission, "exampleClusterRoleName", "exampleSubjectKind", "", "exampleSubjectName")},
+							}))
+							Expect(sp.Status.Plan.RoleBindings).To(Equal(v1alpha1.BindingChanges{
+								Create:   1,
+								Delete:   1,
+								ToCreate: []string{"default/" + staleRoleBinding.Name},
+								ToDelete: []string{"test/" + staleRoleBinding.Name},
+							}))
+							Expect(sp.Status.ClusterRoleBindings).To(BeEmpty())
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("A Permission has an invalid regex and validation is disabled", func() {
+			BeforeEach(func() {
+				testClusterRoleList = rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "exampleClusterRoleName",
+							},
+						},
+					},
+				}
+				testSubjectPermission.Spec.ClusterPermissions = []string{}
+				testSubjectPermission.Spec.Permissions = []v1alpha1.Permission{
+					{
+						ClusterRoleName:        "exampleClusterRoleName",
+						NamespacesAllowedRegex: "[invalid-regex",
+					},
+				}
+			})
+			It("Reports the pattern in the Degraded condition instead of panicking", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{Items: []corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "default"}}}}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							degraded := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionDegraded)
+							Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+							Expect(degraded.Reason).To(Equal(v1alpha1.ReasonInvalidSpec))
+							Expect(degraded.Message).To(ContainSubstring("Permission for exampleClusterRoleName: invalid namespacesAllowedRegex"))
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("The grant policy denies some of the requested bindings", func() {
+			BeforeEach(func() {
+				subjectPermissionReconciler.Config = operatorconfig.NewStore()
+				_, err := subjectPermissionReconciler.Config.Update(&corev1.ConfigMap{
+					Data: map[string]string{
+						controllerutil.PolicyClusterScopeDeniedKey: "cluster-admin",
+						controllerutil.PolicyDeniedNamespacesKey:   "^openshift-.*",
+						operatorconfig.ResyncPeriodKey:             "1h",
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				testClusterRoleList = rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{
+						{ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "exampleClusterRoleName"}},
+					},
+				}
+				testSubjectPermission.Spec.ClusterPermissions = []string{"cluster-admin", "exampleClusterRoleName"}
+				testSubjectPermission.Spec.Permissions = []v1alpha1.Permission{
+					{
+						ClusterRoleName:        "exampleClusterRoleName",
+						NamespacesAllowedRegex: ".*",
+					},
+				}
+			})
+			It("Applies the allowed bindings, revokes the denied ones and reports the violation", func() {
+				deniedCRB := subjectpermission.NewClusterRoleBinding(&testSubjectPermission, "cluster-admin", "exampleSubjectName", "", "exampleSubjectKind")
+				controllerutil.SetOwnershipMetadata(deniedCRB, &testSubjectPermission, "cluster-admin")
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{Items: []rbacv1.ClusterRoleBinding{*deniedCRB}}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, crb *rbacv1.ClusterRoleBinding, co ...client.CreateOption) error {
+							Expect(crb.RoleRef.Name).To(Equal("exampleClusterRoleName"))
+							return nil
+						}),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, crb *rbacv1.ClusterRoleBinding, do ...client.DeleteOption) error {
+							Expect(crb.Name).To(Equal(deniedCRB.Name))
+							return nil
+						}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{Items: []corev1.Namespace{
+						{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "openshift-monitoring"}},
+					}}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, rb *rbacv1.RoleBinding, co ...client.CreateOption) error {
+							Expect(rb.Namespace).To(Equal("default"))
+							return nil
+						}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							degraded := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionDegraded)
+							Expect(degraded.Reason).To(Equal(v1alpha1.ReasonPolicyViolation))
+							Expect(degraded.Message).To(ContainSubstring("ClusterRole denied by the operator policy: cluster-admin"))
+							Expect(degraded.Message).ToNot(ContainSubstring("openshift-monitoring"))
+							Expect(meta.IsStatusConditionTrue(sp.Status.Conditions, v1alpha1.ConditionReady)).To(BeFalse())
+							Expect(sp.Status.ClusterRoleBindings).To(HaveLen(1))
+							Expect(sp.Status.Permissions[0].ProtectedNamespaces).To(Equal([]string{"openshift-monitoring"}))
+							return nil
+						}),
+				)
+				result, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+				// the SubjectPermission is reconciled again after the configured resync period
+				Expect(result.RequeueAfter).To(Equal(time.Hour))
+			})
+			It("Does not report the violation again once the status lists it", func() {
+				testSubjectPermission.Status.DeniedClusterRoles = []string{"cluster-admin"}
+				recorder := events.NewFakeRecorder(10)
+				subjectPermissionReconciler.Recorder = recorder
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(recorder.Events).To(HaveLen(1))
+				Expect(<-recorder.Events).To(HavePrefix("Normal BindingCreated"))
+			})
+		})
+
+		When("The grant policy protects namespaces matched by a Permission", func() {
+			BeforeEach(func() {
+				subjectPermissionReconciler.Config = operatorconfig.NewStore()
+				_, err := subjectPermissionReconciler.Config.Update(&corev1.ConfigMap{
+					Data: map[string]string{
+						controllerutil.PolicyDeniedNamespacesKey:         "^kube-.*",
+						controllerutil.PolicyDeniedNamespaceSelectorsKey: "openshift.io/cluster-monitoring=true",
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				testClusterRoleList = rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{{ObjectMeta: metav1.ObjectMeta{Name: "exampleClusterRoleName"}}},
+				}
+				testSubjectPermission.Spec.ClusterPermissions = nil
+				testSubjectPermission.Spec.Permissions = []v1alpha1.Permission{
+					{
+						ClusterRoleName:        "exampleClusterRoleName",
+						NamespacesAllowedRegex: ".*",
+					},
+				}
+			})
+			It("Excludes and reports the protected namespaces without degrading the SubjectPermission", func() {
+				recorder := events.NewFakeRecorder(10)
+				subjectPermissionReconciler.Recorder = recorder
+				protectedRB := controllerutil.NewRoleBindingForClusterRole(&testSubjectPermission, "exampleClusterRoleName", "exampleSubjectName", "", "exampleSubjectKind", "kube-system")
+				controllerutil.SetOwnershipMetadata(protectedRB, &testSubjectPermission, "exampleClusterRoleName")
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{Items: []corev1.Namespace{
+						{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "monitoring", Labels: map[string]string{"openshift.io/cluster-monitoring": "true"}}},
+					}}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{Items: []rbacv1.RoleBinding{*protectedRB}}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, rb *rbacv1.RoleBinding, co ...client.CreateOption) error {
+							Expect(rb.Namespace).To(Equal("team-a"))
+							return nil
+						}),
+					// the RoleBinding created before the namespace was protected is revoked
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, rb *rbacv1.RoleBinding, do ...client.DeleteOption) error {
+							Expect(rb.Namespace).To(Equal("kube-system"))
+							return nil
+						}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							Expect(meta.IsStatusConditionTrue(sp.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
+							Expect(sp.Status.Permissions).To(ConsistOf(v1alpha1.PermissionStatus{
+								ClusterRoleName:     "exampleClusterRoleName",
+								RoleBindings:        1,
+								ProtectedNamespaces: []string{"kube-system", "monitoring"},
+							}))
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+				// each Event is emitted on the SubjectPermission and on the Namespace
+				Expect(recorder.Events).To(HaveLen(8))
+				var emitted []string
+				for len(recorder.Events) > 0 {
+					emitted = append(emitted, <-recorder.Events)
+				}
+				Expect(emitted).To(ContainElements(
+					"Normal NamespaceExcluded Namespace kube-system is protected by the operator policy, ClusterRole exampleClusterRoleName is not granted in it",
+					"Normal NamespaceExcluded Namespace monitoring is protected by the operator policy, ClusterRole exampleClusterRoleName is not granted in it",
+					HavePrefix("Normal BindingCreated Created RoleBinding"),
+					HavePrefix("Normal BindingRemoved Deleted RoleBinding"),
+				))
+			})
+		})
+
+		When("A Permission binds a Role missing from some allowed namespaces", func() {
+			BeforeEach(func() {
+				testSubjectPermission.Spec.ClusterPermissions = nil
+				testSubjectPermission.Spec.Permissions = []v1alpha1.Permission{
+					{
+						RoleRef:                &v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindRole, Name: "deployer"},
+						NamespacesAllowedRegex: "^team-.*",
+					},
+				}
+			})
+			It("Binds the Role where it exists and reports the other namespaces", func() {
+				recorder := events.NewFakeRecorder(10)
+				subjectPermissionReconciler.Recorder = recorder
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{Items: []corev1.Namespace{
+						{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+					}}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleList{Items: []rbacv1.Role{
+						{ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "team-a"}},
+						{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-b"}},
+					}}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, rb *rbacv1.RoleBinding, co ...client.CreateOption) error {
+							Expect(rb.Namespace).To(Equal("team-a"))
+							Expect(rb.RoleRef).To(Equal(rbacv1.RoleRef{Kind: "Role", Name: "deployer"}))
+							return nil
+						}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							degraded := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionDegraded)
+							Expect(degraded).ToNot(BeNil())
+							Expect(degraded.Reason).To(Equal(v1alpha1.ReasonRoleNotFound))
+							Expect(sp.Status.Permissions).To(ConsistOf(v1alpha1.PermissionStatus{
+								ClusterRoleName:       "deployer",
+								Kind:                  v1alpha1.RoleKindRole,
+								RoleBindings:          1,
+								MissingRoleNamespaces: []string{"team-b"},
+							}))
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+				var emitted []string
+				for len(recorder.Events) > 0 {
+					emitted = append(emitted, <-recorder.Events)
+				}
+				Expect(emitted).To(ContainElements(
+					"Warning RoleMissing Role deployer for Permission does not exist in namespace team-b",
+					HavePrefix("Normal BindingCreated Created RoleBinding"),
+				))
+			})
+		})
+
+		When("The SubjectPermission defines the ClusterRole it grants", func() {
+			var definition v1alpha1.ManagedClusterRole
+
+			BeforeEach(func() {
+				definition = v1alpha1.ManagedClusterRole{
+					Name:  "tenant-deployer",
+					Rules: []rbacv1.PolicyRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "update"}}},
+				}
+				testSubjectPermission.Spec.ClusterRoles = []v1alpha1.ManagedClusterRole{definition}
+				testSubjectPermission.Spec.ClusterPermissions = []string{"tenant-deployer"}
+				testSubjectPermission.Spec.Permissions = nil
+			})
+
+			It("Creates the ClusterRole before binding it", func() {
+				recorder := events.NewFakeRecorder(10)
+				subjectPermissionReconciler.Recorder = recorder
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleList{}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, cr *rbacv1.ClusterRole, co ...client.CreateOption) error {
+							Expect(cr.Name).To(Equal("tenant-deployer"))
+							Expect(cr.Rules).To(Equal(definition.Rules))
+							Expect(controllerutil.IsOwnedBy(cr, &testSubjectPermission)).To(BeTrue())
+							return nil
+						}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, crb *rbacv1.ClusterRoleBinding, co ...client.CreateOption) error {
+							Expect(crb.RoleRef.Name).To(Equal("tenant-deployer"))
+							return nil
+						}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							Expect(meta.IsStatusConditionTrue(sp.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
+							Expect(sp.Status.ClusterRoles).To(Equal([]string{"tenant-deployer"}))
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(<-recorder.Events).To(Equal("Normal ClusterRoleCreated Created ClusterRole tenant-deployer"))
+			})
+
+			It("Does not bind a wildcard ClusterRole it defines once the policy limits the cluster scope", func() {
+				subjectPermissionReconciler.Config = operatorconfig.NewStore()
+				_, err := subjectPermissionReconciler.Config.Update(&corev1.ConfigMap{
+					Data: map[string]string{controllerutil.PolicyClusterScopeDeniedKey: "cluster-admin"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				// the rules of cluster-admin under another name
+				testSubjectPermission.Spec.ClusterRoles = []v1alpha1.ManagedClusterRole{{
+					Name:  "tenant-deployer",
+					Rules: []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+				}}
+				recorder := events.NewFakeRecorder(10)
+				subjectPermissionReconciler.Recorder = recorder
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleList{}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.AssignableToTypeOf(&rbacv1.ClusterRole{})).Times(1).Return(nil),
+					// no ClusterRoleBinding is created
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							degraded := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionDegraded)
+							Expect(degraded).ToNot(BeNil())
+							Expect(degraded.Reason).To(Equal(v1alpha1.ReasonPolicyViolation))
+							Expect(degraded.Message).To(ContainSubstring("ClusterRole denied by the operator policy: tenant-deployer"))
+							Expect(sp.Status.ClusterRoleBindings).To(BeEmpty())
+							return nil
+						}),
+				)
+				_, err = subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(<-recorder.Events).To(Equal("Normal ClusterRoleCreated Created ClusterRole tenant-deployer"))
+				Expect(<-recorder.Events).To(Equal("Warning PolicyViolation ClusterRole tenant-deployer denied at cluster scope by the operator policy"))
+			})
+
+			It("Leaves a ClusterRole of the same name it did not create untouched", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleList{
+						Items: []rbacv1.ClusterRole{{ObjectMeta: metav1.ObjectMeta{Name: "tenant-deployer"}}},
+					}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.AssignableToTypeOf(&rbacv1.ClusterRoleBinding{})).Times(1).Return(nil),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							degraded := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionDegraded)
+							Expect(degraded).ToNot(BeNil())
+							Expect(degraded.Reason).To(Equal(v1alpha1.ReasonClusterRoleConflict))
+							Expect(degraded.Message).To(Equal("ClusterRole already exists and was not created for the SubjectPermission: tenant-deployer"))
+							Expect(sp.Status.ClusterRoles).To(BeEmpty())
+							return nil
+						}),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("Deletes the ClusterRole once it is no longer defined", func() {
+				owned := controllerutil.NewManagedClusterRole(&testSubjectPermission, definition)
+				testSubjectPermission.Spec.ClusterRoles = nil
+				testSubjectPermission.Spec.ClusterPermissions = nil
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleList{Items: []rbacv1.ClusterRole{*owned}}),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, cr *rbacv1.ClusterRole, do ...client.DeleteOption) error {
+							Expect(cr.Name).To(Equal("tenant-deployer"))
+							return nil
+						}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+				)
+				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("The SubjectPermission is time-bound", func() {
+			BeforeEach(func() {
+				testSubjectPermission.Spec.SubjectKind = "Group"
+				testSubjectPermission.Spec.ClusterPermissions = nil
+				testSubjectPermission.Spec.Permissions = nil
 			})
-			It("Updates status condition for successful creation of ClusterRoleBindings", func() {
+
+			It("Requeues the SubjectPermission when its duration elapses", func() {
+				testSubjectPermission.CreationTimestamp = metav1.Now()
+				testSubjectPermission.Spec.Duration = &metav1.Duration{Duration: time.Hour}
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
-					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).SetArg(1, testconst.TestClusterRoleBinding),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
 					mockClient.EXPECT().Status().Return(mockStatusWriter),
 					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
-						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
-							Expect(sp.Status.Conditions[1].Message).To(Equal("Successfully created all ClusterRoleBindings"))
-							Expect(sp.Status.Conditions[1].ClusterRoleNames).To(ContainElement(ContainSubstring("exampleClusterRoleName")))
-							Expect(sp.Status.Conditions[1].ClusterRoleNames).To(ContainElement(ContainSubstring("exampleClusterRoleNameTwo")))
-							Expect(sp.Status.Conditions[1].Status).To(Equal(true))
-							Expect(sp.Status.Conditions[1].State).To(Equal(v1alpha1.SubjectPermissionStateCreated))
-							Expect(sp.Status.Conditions[1].Type).To(Equal(v1alpha1.ClusterRoleBindingCreated))
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							Expect(meta.IsStatusConditionTrue(sp.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
+							Expect(sp.Status.ExpiresAt.Time).To(BeTemporally("==", testSubjectPermission.CreationTimestamp.Add(time.Hour)))
 							return nil
 						}),
 				)
-				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				result, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
 			})
-		})
 
-		When("There are no ClusterPermissions and allowed namespaces for RoleBinding creation", func() {
-			BeforeEach(func() {
-				testClusterRoleList = rbacv1.ClusterRoleList{
-					Items: []rbacv1.ClusterRole{
-						{
-							ObjectMeta: metav1.ObjectMeta{
-								Name: "exampleClusterRoleName",
-							},
-						},
-					},
-				}
-				testSubjectPermission = v1alpha1.SubjectPermission{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "testSubjectPermission",
-						Namespace: "rbac-permissions-operator",
-					},
-					Spec: v1alpha1.SubjectPermissionSpec{
-						SubjectName:        "exampleSubjectName",
-						SubjectKind:        "exampleSubjectKind",
-						ClusterPermissions: []string{},
-						Permissions:        []v1alpha1.Permission{},
-					},
-					Status: v1alpha1.SubjectPermissionStatus{
-						Conditions: []v1alpha1.Condition{},
-					},
-				}
-				testNamespaceList = &corev1.NamespaceList{
-					Items: []corev1.Namespace{
+			It("Revokes the bindings once it expired and marks it Expired", func() {
+				recorder := events.NewFakeRecorder(10)
+				subjectPermissionReconciler.Recorder = recorder
+				expiresAt := metav1.NewTime(time.Now().Add(-time.Minute))
+				testSubjectPermission.Spec.ExpiresAt = &expiresAt
+				testSubjectPermission.Status.ClusterRoleBindings = []string{"exampleClusterRoleName-exampleSubjectName"}
+				clusterRoleBindings := rbacv1.ClusterRoleBindingList{
+					Items: []rbacv1.ClusterRoleBinding{
 						{
-							ObjectMeta: metav1.ObjectMeta{
-								Name: "test",
-							},
+							ObjectMeta: metav1.ObjectMeta{Name: "exampleClusterRoleName-exampleSubjectName"},
+							Subjects:   []rbacv1.Subject{{Kind: "Group", Name: "exampleSubjectName"}},
+							RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "exampleClusterRoleName"},
 						},
 					},
 				}
-			})
-			It("Should successfully reconcile", func() {
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, clusterRoleBindings),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							ready := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionReady)
+							Expect(ready).ToNot(BeNil())
+							Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+							Expect(ready.Reason).To(Equal(v1alpha1.ReasonExpired))
+							Expect(meta.IsStatusConditionFalse(sp.Status.Conditions, v1alpha1.ConditionDegraded)).To(BeTrue())
+							Expect(sp.Status.ClusterRoleBindings).To(BeEmpty())
+							Expect(sp.Status.ExpiresAt).To(Equal(&expiresAt))
+							return nil
+						}),
 				)
-				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				result, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+				Expect(<-recorder.Events).To(HavePrefix("Normal BindingRemoved Deleted ClusterRoleBinding exampleClusterRoleName-exampleSubjectName"))
+				Expect(<-recorder.Events).To(Equal("Normal Expired SubjectPermission expired at " + expiresAt.UTC().Format(time.RFC3339)))
 			})
-		})
 
-		When("ClusterRoleName does not exist as a Role", func() {
-			BeforeEach(func() {
-				testClusterRoleList = rbacv1.ClusterRoleList{
-					Items: []rbacv1.ClusterRole{
-						{
-							ObjectMeta: metav1.ObjectMeta{
-								Name: "exampleClusterRoleName",
-							},
-						},
-					},
-				}
-				testSubjectPermission = v1alpha1.SubjectPermission{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "testSubjectPermission",
-						Namespace: "rbac-permissions-operator",
-					},
-					Spec: v1alpha1.SubjectPermissionSpec{
-						SubjectName:        "exampleSubjectName",
-						SubjectKind:        "exampleSubjectKind",
-						ClusterPermissions: []string{},
-						Permissions: []v1alpha1.Permission{
-							{
-								ClusterRoleName:        "exampleClusterRoleName",
-								NamespacesAllowedRegex: testconst.TestDefaultAllowedList,
-								NamespacesDeniedRegex:  testconst.TestEmptyDeniedList,
-							},
-							{
-								ClusterRoleName:        "testClusterRoleName",
-								NamespacesAllowedRegex: "test-namespace",
-								NamespacesDeniedRegex:  "",
-							},
-						},
-					},
-					Status: v1alpha1.SubjectPermissionStatus{
-						Conditions: []v1alpha1.Condition{},
-					},
-				}
-				testNamespaceList = &corev1.NamespaceList{
-					Items: []corev1.Namespace{
-						{
-							ObjectMeta: metav1.ObjectMeta{
-								Name: "test",
-							},
-						},
-					},
-				}
-			})
-			It("Should reconcile successfully if any ClusterRoleName does not exist as a Role", func() {
+			It("Does not revoke the bindings of an expired SubjectPermission in DryRun mode", func() {
+				expiresAt := metav1.NewTime(time.Now().Add(-time.Minute))
+				testSubjectPermission.Spec.ExpiresAt = &expiresAt
+				testSubjectPermission.Spec.Mode = v1alpha1.ModeDryRun
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
 					mockClient.EXPECT().Status().Return(mockStatusWriter),
 					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
-						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
-							Expect(sp.Status.Conditions[0].Message).To(Equal("Role for Permission does not exist"))
-							Expect(sp.Status.Conditions[0].ClusterRoleNames[0]).To(Equal("testClusterRoleName"))
-							Expect(sp.Status.Conditions[0].Status).To(Equal(true))
-							Expect(sp.Status.Conditions[0].State).To(Equal(v1alpha1.SubjectPermissionStateFailed))
-							Expect(sp.Status.Conditions[0].Type).To(Equal(v1alpha1.RoleBindingCreated))
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							ready := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionReady)
+							Expect(ready).ToNot(BeNil())
+							Expect(ready.Reason).To(Equal(v1alpha1.ReasonExpired))
+							Expect(ready.Message).To(ContainSubstring("bindings are not revoked in DryRun mode"))
 							return nil
 						}),
 				)
 				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).ToNot(HaveOccurred())
 			})
-
 		})
 
-		When("RoleBindings are created in allowed namespaces", func() {
+		When("A namespace with a RoleBinding is no longer allowed", func() {
 			BeforeEach(func() {
 				testClusterRoleList = rbacv1.ClusterRoleList{
 					Items: []rbacv1.ClusterRole{
@@ -251,62 +1288,60 @@ var _ = Describe("SubjectPermission Controller", func() {
 						},
 					},
 				}
-				testSubjectPermission = v1alpha1.SubjectPermission{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      "testSubjectPermission",
-						Namespace: "rbac-permissions-operator",
-					},
-					Spec: v1alpha1.SubjectPermissionSpec{
-						SubjectName:        "exampleSubjectName",
-						SubjectKind:        "exampleSubjectKind",
-						ClusterPermissions: []string{},
-						Permissions: []v1alpha1.Permission{
-							{
-								ClusterRoleName:        "exampleClusterRoleName",
-								NamespacesAllowedRegex: testconst.TestDefaultAllowedList,
-								NamespacesDeniedRegex:  testconst.TestEmptyDeniedList,
-							},
-						},
-					},
-					Status: v1alpha1.SubjectPermissionStatus{
-						Conditions: []v1alpha1.Condition{},
+				testSubjectPermission.Spec.ClusterPermissions = []string{}
+				testSubjectPermission.Spec.Permissions = []v1alpha1.Permission{
+					{
+						ClusterRoleName:        "exampleClusterRoleName",
+						NamespacesAllowedRegex: testconst.TestDefaultAllowedList,
 					},
 				}
 				testNamespaceList = &corev1.NamespaceList{
 					Items: []corev1.Namespace{
 						{
 							ObjectMeta: metav1.ObjectMeta{
-								Name: "test",
+								Name: "default",
 							},
 						},
 						{
 							ObjectMeta: metav1.ObjectMeta{
-								Name: "default",
+								Name: "test",
 							},
 						},
 					},
 				}
 			})
-			It("Should update the status condition for successful RoleBinding creation", func() {
+			It("Deletes the RoleBinding from the namespace", func() {
+				staleRoleBinding := rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "exampleClusterRoleName-exampleSubjectName",
+						Namespace: "test",
+					},
+					Subjects: []rbacv1.Subject{
+						{
+							Kind: "exampleSubjectKind",
+							Name: "exampleSubjectName",
+						},
+					},
+					RoleRef: rbacv1.RoleRef{
+						Kind: "ClusterRole",
+						Name: "exampleClusterRoleName",
+					},
+				}
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
-						client.InNamespace("default"),
-					}),
-					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).SetArg(1, *testconst.TestRoleBinding),
-					mockClient.EXPECT().Status().Return(mockStatusWriter),
-					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
-						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
-							Expect(sp.Status.Conditions[0].Message).To(Equal("Successfully created all roleBindings"))
-							Expect(sp.Status.Conditions[0].ClusterRoleNames[0]).To(Equal("exampleClusterRoleName"))
-							Expect(sp.Status.Conditions[0].Status).To(Equal(true))
-							Expect(sp.Status.Conditions[0].State).To(Equal(v1alpha1.SubjectPermissionStateCreated))
-							Expect(sp.Status.Conditions[0].Type).To(Equal(v1alpha1.RoleBindingCreated))
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{Items: []rbacv1.RoleBinding{staleRoleBinding}}),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, rb *rbacv1.RoleBinding, do ...client.DeleteOption) error {
+							Expect(rb.Name).To(Equal(staleRoleBinding.Name))
+							Expect(rb.Namespace).To(Equal("test"))
 							return nil
 						}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
 				)
 				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).ToNot(HaveOccurred())
@@ -358,6 +1393,8 @@ var _ = Describe("SubjectPermission Controller", func() {
 					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).SetArg(2, testSubjectPermission),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
 					mockClient.EXPECT().Status().Return(mockStatusWriter),
 					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).SetArg(1, testSubjectPermission).Return(fmt.Errorf("fake error")),
 				)
@@ -366,7 +1403,7 @@ var _ = Describe("SubjectPermission Controller", func() {
 			})
 		})
 
-		When("Not able to update condition for successful ClusterRoleBindings creation", func() {
+		When("Not able to create a ClusterRoleBinding", func() {
 			BeforeEach(func() {
 				testClusterRoleList = rbacv1.ClusterRoleList{
 					Items: []rbacv1.ClusterRole{
@@ -383,12 +1420,25 @@ var _ = Describe("SubjectPermission Controller", func() {
 					},
 				}
 			})
-			It("Should report failure", func() {
+			It("Should apply the other bindings and report failure", func() {
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, testSubjectPermission),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
-					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testconst.TestClusterRoleBinding).Return(fmt.Errorf("fake error")),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(fmt.Errorf("fake error")),
+					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.SubResourceUpdateOption) error {
+							degraded := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionDegraded)
+							Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+							Expect(degraded.Reason).To(Equal(v1alpha1.ReasonBindingsFailed))
+							Expect(meta.IsStatusConditionTrue(sp.Status.Conditions, v1alpha1.ConditionProgressing)).To(BeTrue())
+							Expect(sp.Status.ClusterRoleBindings).To(HaveLen(1))
+							return nil
+						}),
 				)
 				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).To(HaveOccurred())
@@ -418,6 +1468,8 @@ var _ = Describe("SubjectPermission Controller", func() {
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
 					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(2).SetArg(1, testconst.TestClusterRoleBinding),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
 					mockClient.EXPECT().Status().Return(mockStatusWriter),
 					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).SetArg(1, testSubjectPermission).Return(fmt.Errorf("fake error")),
 				)
@@ -449,7 +1501,7 @@ var _ = Describe("SubjectPermission Controller", func() {
 						Permissions:        []v1alpha1.Permission{},
 					},
 					Status: v1alpha1.SubjectPermissionStatus{
-						Conditions: []v1alpha1.Condition{},
+						Conditions: []metav1.Condition{},
 					},
 				}
 				testNamespaceList = &corev1.NamespaceList{
@@ -508,7 +1560,7 @@ var _ = Describe("SubjectPermission Controller", func() {
 						},
 					},
 					Status: v1alpha1.SubjectPermissionStatus{
-						Conditions: []v1alpha1.Condition{},
+						Conditions: []metav1.Condition{},
 					},
 				}
 				testNamespaceList = &corev1.NamespaceList{
@@ -527,6 +1579,7 @@ var _ = Describe("SubjectPermission Controller", func() {
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
 					mockClient.EXPECT().Status().Return(mockStatusWriter),
 					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).SetArg(1, testSubjectPermission).Return(fmt.Errorf("fake error")),
 				)
@@ -564,7 +1617,7 @@ var _ = Describe("SubjectPermission Controller", func() {
 						},
 					},
 					Status: v1alpha1.SubjectPermissionStatus{
-						Conditions: []v1alpha1.Condition{},
+						Conditions: []metav1.Condition{},
 					},
 				}
 				testNamespaceList = &corev1.NamespaceList{
@@ -588,10 +1641,18 @@ var _ = Describe("SubjectPermission Controller", func() {
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
-						client.InNamespace("default"),
-					}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
 					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).SetArg(1, *testconst.TestRoleBinding).Return(fmt.Errorf("fake error")),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
+							Expect(sp.Status.Permissions).To(HaveLen(1))
+							Expect(sp.Status.Permissions[0].ClusterRoleName).To(Equal("exampleClusterRoleName"))
+							Expect(sp.Status.Permissions[0].RoleBindings).To(BeZero())
+							Expect(sp.Status.Permissions[0].FailedNamespaces).To(ConsistOf(v1alpha1.NamespaceFailure{Namespace: "default", Reason: "fake error"}))
+							Expect(meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionDegraded).Reason).To(Equal(v1alpha1.ReasonBindingsFailed))
+							return nil
+						}),
 				)
 				_, err := subjectPermissionReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).To(HaveOccurred())
@@ -627,7 +1688,7 @@ var _ = Describe("SubjectPermission Controller", func() {
 						},
 					},
 					Status: v1alpha1.SubjectPermissionStatus{
-						Conditions: []v1alpha1.Condition{},
+						Conditions: []metav1.Condition{},
 					},
 				}
 				testNamespaceList = &corev1.NamespaceList{
@@ -652,9 +1713,7 @@ var _ = Describe("SubjectPermission Controller", func() {
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, *testNamespaceList),
-					mockClient.EXPECT().List(gomock.Any(), gomock.Any(), []client.ListOption{
-						client.InNamespace("default"),
-					}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
 					mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).SetArg(1, *testconst.TestRoleBinding),
 					mockClient.EXPECT().Status().Return(mockStatusWriter),
 					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).SetArg(1, testSubjectPermission).Return(fmt.Errorf("fake error")),
@@ -668,8 +1727,8 @@ var _ = Describe("SubjectPermission Controller", func() {
 	Context("Testing NewClusterRoleBinding function", func() {
 		When("A ClusterRoleName, SubjectName and SubjectKind are given", func() {
 			It("Should return a ClusterRoleBinding", func() {
-				crb := subjectpermission.NewClusterRoleBinding(testClusterRoleName, testSubjectName, testSubjectKind)
-				Expect(crb.Name).To(Equal(testClusterRoleName + "-" + testSubjectName))
+				crb := subjectpermission.NewClusterRoleBinding(&testSubjectPermission, testClusterRoleName, testSubjectName, "", testSubjectKind)
+				Expect(crb.Name).To(Equal(controllerutil.BindingName(&testSubjectPermission, testClusterRoleName, testSubjectKind, "", testSubjectName)))
 				Expect(crb.Subjects[0].Kind).To(Equal(testSubjectKind))
 				Expect(crb.Subjects[0].Name).To(Equal(testSubjectName))
 				Expect(crb.RoleRef.Kind).To(Equal("ClusterRole"))
@@ -770,7 +1829,15 @@ var _ = Describe("SubjectPermission Controller", func() {
 				gomock.InOrder(
 					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, invalidSP),
 					mockClient.EXPECT().Status().Return(mockStatusWriter),
-					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
+							degraded := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionDegraded)
+							Expect(degraded).ToNot(BeNil())
+							Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+							Expect(degraded.Reason).To(Equal(v1alpha1.ReasonInvalidSpec))
+							Expect(degraded.Message).To(ContainSubstring("subjectKind must be one of"))
+							return nil
+						}),
 				)
 				_, err := validationReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).To(HaveOccurred())
@@ -778,6 +1845,29 @@ var _ = Describe("SubjectPermission Controller", func() {
 			})
 		})
 
+		When("SubjectPermission has a ServiceAccount subject without a namespace", func() {
+			It("Should fail validation and report the spec as invalid", func() {
+				invalidSP := *testSubjectPermission.DeepCopy()
+				invalidSP.Spec.SubjectKind = "ServiceAccount"
+				invalidSP.Spec.SubjectNamespace = ""
+
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, invalidSP),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
+							degraded := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionDegraded)
+							Expect(degraded).ToNot(BeNil())
+							Expect(degraded.Reason).To(Equal(v1alpha1.ReasonInvalidSpec))
+							Expect(degraded.Message).To(ContainSubstring("subjectNamespace cannot be empty for a ServiceAccount"))
+							return nil
+						}),
+				)
+				_, err := validationReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
 		When("SubjectPermission has invalid regex in permissions", func() {
 			It("Should fail validation and return error", func() {
 				invalidSP := testSubjectPermission
@@ -822,6 +1912,25 @@ var _ = Describe("SubjectPermission Controller", func() {
 			})
 		})
 
+		When("SubjectPermission has an invalid entry in subjects", func() {
+			It("Should fail validation and return error", func() {
+				invalidSP := testSubjectPermission
+				invalidSP.Spec.SubjectName = ""
+				invalidSP.Spec.SubjectKind = ""
+				invalidSP.Spec.Subjects = []rbacv1.Subject{{Kind: "InvalidKind", Name: "someone"}}
+
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, invalidSP),
+					mockClient.EXPECT().Status().Return(mockStatusWriter),
+					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+				)
+				_, err := validationReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("spec.subjects[0].kind"))
+				Expect(err.Error()).ToNot(ContainSubstring("subjectName cannot be empty"))
+			})
+		})
+
 		When("SubjectPermission has empty ClusterRoleName in permission", func() {
 			It("Should fail validation and return error", func() {
 				invalidSP := testSubjectPermission
@@ -909,6 +2018,8 @@ var _ = Describe("SubjectPermission Controller", func() {
 					// Validation passes, but may still call Status for validation success
 					mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes(),
 					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).AnyTimes().Return(nil),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
 					mockClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
 				)
 				result, err := enhancedReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
@@ -981,12 +2092,14 @@ var _ = Describe("SubjectPermission Controller", func() {
 					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, spWithMissingRoles),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList),
 					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, corev1.NamespaceList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
 					mockClient.EXPECT().Status().Return(mockStatusWriter),
 					mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(statusError),
 				)
 				_, err := enhancedReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(ContainSubstring("failed to update status for missing ClusterRoles"))
+				Expect(err.Error()).To(ContainSubstring("failed to update SubjectPermission status"))
 			})
 		})
 
@@ -1005,6 +2118,8 @@ var _ = Describe("SubjectPermission Controller", func() {
 				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList)
 				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList)
 				mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(createError)
+				// the namespace scope is still applied after the failure
+				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(2).Return(nil)
 				_, err := enhancedReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("failed to create ClusterRoleBinding"))
@@ -1035,6 +2150,126 @@ var _ = Describe("SubjectPermission Controller", func() {
 			})
 		})
 
+		When("SubjectPermission with bindings on the cluster is being deleted", func() {
+			It("Should delete the bindings before removing the finalizer", func() {
+				deletingSP := testSubjectPermission
+				deletingSP.Spec.SubjectKind = "Group"
+				now := metav1.Now()
+				deletingSP.DeletionTimestamp = &now
+				deletingSP.Finalizers = []string{"subjectpermission.managed.openshift.io/finalizer"}
+				subjects := []rbacv1.Subject{
+					{
+						Kind: "Group",
+						Name: "exampleSubjectName",
+					},
+				}
+				clusterRoleBindings := rbacv1.ClusterRoleBindingList{
+					Items: []rbacv1.ClusterRoleBinding{
+						testconst.TestClusterRoleBinding,
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "exampleClusterRoleName-exampleSubjectName"},
+							Subjects:   subjects,
+							RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "exampleClusterRoleName"},
+						},
+					},
+				}
+				roleBindings := rbacv1.RoleBindingList{
+					Items: []rbacv1.RoleBinding{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "exampleClusterRoleName-exampleSubjectName", Namespace: "default"},
+							Subjects:   subjects,
+							RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "exampleClusterRoleName"},
+						},
+					},
+				}
+
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, deletingSP),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, clusterRoleBindings),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, roleBindings),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+					mockClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+				)
+				_, err := enhancedReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("Another SubjectPermission grants the same ClusterRole to the same subject", func() {
+			It("Should only delete the binding of the deleted SubjectPermission", func() {
+				deletingSP := testSubjectPermission
+				deletingSP.Spec.SubjectKind = "Group"
+				now := metav1.Now()
+				deletingSP.DeletionTimestamp = &now
+				deletingSP.Finalizers = []string{"subjectpermission.managed.openshift.io/finalizer"}
+				otherSP := deletingSP.DeepCopy()
+				otherSP.Name = "otherSubjectPermission"
+				otherSP.DeletionTimestamp = nil
+
+				ownCRB := subjectpermission.NewClusterRoleBinding(&deletingSP, "exampleClusterRoleName", "exampleSubjectName", "", "Group")
+				controllerutil.SetOwnershipMetadata(ownCRB, &deletingSP, "exampleClusterRoleName")
+				otherCRB := subjectpermission.NewClusterRoleBinding(otherSP, "exampleClusterRoleName", "exampleSubjectName", "", "Group")
+				controllerutil.SetOwnershipMetadata(otherCRB, otherSP, "exampleClusterRoleName")
+				Expect(otherCRB.Name).ToNot(Equal(ownCRB.Name))
+
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, deletingSP),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{Items: []rbacv1.ClusterRoleBinding{*ownCRB, *otherCRB}}),
+					mockClient.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, crb *rbacv1.ClusterRoleBinding, do ...client.DeleteOption) error {
+							Expect(crb.Name).To(Equal(ownCRB.Name))
+							return nil
+						}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+				)
+				_, err := enhancedReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("An invalid SubjectPermission is being deleted", func() {
+			It("Should clean up and remove the finalizer without validating the spec", func() {
+				deletingSP := testSubjectPermission
+				deletingSP.Spec.SubjectName = ""
+				now := metav1.Now()
+				deletingSP.DeletionTimestamp = &now
+				deletingSP.Finalizers = []string{"subjectpermission.managed.openshift.io/finalizer"}
+
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, deletingSP),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.ClusterRoleBindingList{}),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, rbacv1.RoleBindingList{}),
+					mockClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+						func(ctx context.Context, sp *v1alpha1.SubjectPermission, uo ...client.UpdateOption) error {
+							Expect(sp.Finalizers).To(BeEmpty())
+							return nil
+						}),
+				)
+				_, err := enhancedReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
+		When("Binding cleanup fails during deletion", func() {
+			It("Should keep the finalizer and return error", func() {
+				deletingSP := testSubjectPermission
+				deletingSP.Spec.SubjectKind = "Group"
+				now := metav1.Now()
+				deletingSP.DeletionTimestamp = &now
+				deletingSP.Finalizers = []string{"subjectpermission.managed.openshift.io/finalizer"}
+
+				gomock.InOrder(
+					mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, deletingSP),
+					mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).Return(fmt.Errorf("list failed")),
+				)
+				_, err := enhancedReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to clean up bindings"))
+			})
+		})
+
 		When("Finalizer removal fails during deletion", func() {
 			It("Should return error with proper metrics", func() {
 				deletingSP := testSubjectPermission
@@ -1047,6 +2282,7 @@ var _ = Describe("SubjectPermission Controller", func() {
 				mockClient.EXPECT().Get(gomock.Any(), testconst.TestNamespaceName, gomock.Any()).Times(1).SetArg(2, deletingSP)
 				mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
 				mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(2).Return(nil)
 				mockClient.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(updateError)
 
 				_, err := enhancedReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
@@ -1112,12 +2348,13 @@ var _ = Describe("SubjectPermission Controller", func() {
 				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleList)
 				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(1).SetArg(1, testClusterRoleBindingList)
 				mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+				mockClient.EXPECT().List(gomock.Any(), gomock.Any()).Times(2).Return(nil)
 				mockClient.EXPECT().Status().Return(mockStatusWriter).AnyTimes()
 				mockStatusWriter.EXPECT().Update(gomock.Any(), gomock.Any()).Times(1).Return(updateError)
 
 				_, err := enhancedReconciler.Reconcile(testconst.Context, reconcile.Request{NamespacedName: testconst.TestNamespaceName})
 				Expect(err).To(HaveOccurred())
-				Expect(err.Error()).To(Equal("status update failed"))
+				Expect(err).To(MatchError(updateError))
 			})
 		})
 
@@ -0,0 +1,359 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subjectpermission
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	controllerutil "github.com/openshift/rbac-permissions-operator/pkg/controllerutils"
+	localmetrics "github.com/openshift/rbac-permissions-operator/pkg/metrics"
+	"github.com/openshift/rbac-permissions-operator/pkg/operatorconfig"
+)
+
+var webhookLog = log.WithName("webhook")
+
+// maxDeniedNamespaces is the number of namespaces listed when the author of a SubjectPermission
+// cannot create RoleBindings in some of the namespaces it matches
+const maxDeniedNamespaces = 5
+
+//+kubebuilder:webhook:path=/validate-managed-openshift-io-v1alpha1-subjectpermission,mutating=false,failurePolicy=fail,sideEffects=None,groups=managed.openshift.io,resources=subjectpermissions,verbs=create;update,versions=v1alpha1,name=vsubjectpermission.managed.openshift.io,admissionReviewVersions=v1
+
+// SubjectPermissionValidator validates SubjectPermissions at admission time.
+// A SubjectPermission is namespaced, so its author is only allowed the bindings they could create themselves
+type SubjectPermissionValidator struct {
+	// Client is used to look up the ClusterRoles referenced by the SubjectPermission,
+	// and to review the access of its author
+	Client client.Client
+	// Config toggles the author review and the missing ClusterRole warnings, both are enabled without it
+	Config *operatorconfig.Store
+}
+
+var _ admission.Validator[*managedv1alpha1.SubjectPermission] = &SubjectPermissionValidator{}
+
+// ValidateCreate rejects invalid SubjectPermissions and warns about missing ClusterRoles
+func (v *SubjectPermissionValidator) ValidateCreate(ctx context.Context, sp *managedv1alpha1.SubjectPermission) (admission.Warnings, error) {
+	return v.validate(ctx, sp, true)
+}
+
+// ValidateUpdate rejects invalid SubjectPermissions and warns about missing ClusterRoles
+func (v *SubjectPermissionValidator) ValidateUpdate(ctx context.Context, oldSP, newSP *managedv1alpha1.SubjectPermission) (admission.Warnings, error) {
+	// let objects that are being deleted through, so an invalid spec never blocks finalizer removal
+	if newSP.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	// the author is only checked when the spec changes, so metadata updates such as finalizers go through
+	return v.validate(ctx, newSP, !equality.Semantic.DeepEqual(oldSP.Spec, newSP.Spec))
+}
+
+// ValidateDelete allows every deletion
+func (v *SubjectPermissionValidator) ValidateDelete(ctx context.Context, sp *managedv1alpha1.SubjectPermission) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate runs the spec validation shared with the reconciler, then checks the author of the SubjectPermission
+func (v *SubjectPermissionValidator) validate(ctx context.Context, sp *managedv1alpha1.SubjectPermission, authorize bool) (admission.Warnings, error) {
+	if err := validateSpec("SubjectPermission", sp.Name, &sp.Spec); err != nil {
+		return nil, err
+	}
+	features := v.Config.Get().Features
+	if authorize && features.AuthorReview {
+		allErrs, err := v.authorize(ctx, sp)
+		if err != nil {
+			webhookLog.Error(err, "Failed to review the access of the author", "namespace", sp.Namespace, "name", sp.Name)
+			return nil, k8serr.NewInternalError(err)
+		}
+		if len(allErrs) != 0 {
+			localmetrics.IncValidationFailures("authorization")
+			return nil, k8serr.NewForbidden(managedv1alpha1.GroupVersion.WithResource("subjectpermissions").GroupResource(), sp.Name, allErrs.ToAggregate())
+		}
+	}
+	if !features.MissingClusterRoleWarnings {
+		return nil, nil
+	}
+	return missingClusterRoleWarnings(ctx, v.Client, &sp.Spec), nil
+}
+
+// authorize checks that the author of the SubjectPermission could create its bindings themselves: ClusterRoleBindings
+// for clusterPermissions and RoleBindings for permissions, binding each of their roles, and the ClusterRoles of
+// clusterRoles with rules they could escalate to. An author who cannot bind the role of a Permission in every namespace
+// must list its namespaces in namespacesAllowedRegex, so the namespaces created later are reviewed as well.
+// Cluster wide grants belong in a ClusterSubjectPermission
+func (v *SubjectPermissionValidator) authorize(ctx context.Context, sp *managedv1alpha1.SubjectPermission) (field.ErrorList, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reviewer := &accessReviewer{client: v.Client, user: req.UserInfo, decisions: map[authorizationv1.ResourceAttributes]bool{}}
+	username := req.UserInfo.Username
+
+	var allErrs field.ErrorList
+	if len(sp.Spec.ClusterPermissions) != 0 {
+		allowed, err := reviewer.can(ctx, "create", "clusterrolebindings", "", "")
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "clusterPermissions"),
+				fmt.Sprintf("%s cannot create ClusterRoleBindings, grant cluster wide permissions with a ClusterSubjectPermission", username)))
+		}
+		for i, clusterRoleName := range sp.Spec.ClusterPermissions {
+			allowed, err := reviewer.can(ctx, "bind", "clusterroles", "", clusterRoleName)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "clusterPermissions").Index(i),
+					fmt.Sprintf("%s cannot bind ClusterRole %s", username, clusterRoleName)))
+			}
+		}
+	}
+	if len(sp.Spec.ClusterRoles) != 0 {
+		allowed, err := reviewer.can(ctx, "create", "clusterroles", "", "")
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "clusterRoles"),
+				fmt.Sprintf("%s cannot create ClusterRoles", username)))
+		}
+		// the operator holds every permission, the rules are only checked against the author through escalate
+		for i, definition := range sp.Spec.ClusterRoles {
+			allowed, err := reviewer.can(ctx, "escalate", "clusterroles", "", definition.Name)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "clusterRoles").Index(i),
+					fmt.Sprintf("%s cannot escalate ClusterRole %s", username, definition.Name)))
+			}
+		}
+	}
+
+	for i, permission := range sp.Spec.Permissions {
+		role := permission.Role()
+		permissionPath := field.NewPath("spec", "permissions").Index(i)
+		// an author allowed to bind the role in every namespace needs no further check
+		allowed, err := reviewer.canBind(ctx, role, "")
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			continue
+		}
+		matcher, err := controllerutil.NewPermissionMatcher(permission)
+		if err != nil {
+			// already rejected by the spec validation
+			continue
+		}
+		namespaces, listed := matcher.ListedNamespaces()
+		if !listed {
+			allErrs = append(allErrs, field.Forbidden(permissionPath.Child("namespacesAllowedRegex"),
+				fmt.Sprintf("%s cannot bind %s %s in every namespace, list the namespaces such as ^(team-a|team-b)$", username, role.Kind, role.Name)))
+			continue
+		}
+		var denied []string
+		for _, ns := range namespaces {
+			allowed, err := reviewer.canBind(ctx, role, ns)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				denied = append(denied, ns)
+			}
+		}
+		if len(denied) != 0 {
+			allErrs = append(allErrs, field.Forbidden(permissionPath,
+				fmt.Sprintf("%s cannot bind %s %s in namespaces %s", username, role.Kind, role.Name, namespaceSummary(denied))))
+		}
+	}
+	return allErrs, nil
+}
+
+// accessReviewer asks the API server what the author of a SubjectPermission may do, once per attributes
+type accessReviewer struct {
+	client    client.Client
+	user      authenticationv1.UserInfo
+	decisions map[authorizationv1.ResourceAttributes]bool
+}
+
+// canBind checks if the user may create RoleBindings of the role in the namespace, or in every namespace for an
+// empty namespace
+func (r *accessReviewer) canBind(ctx context.Context, role managedv1alpha1.PermissionRoleRef, namespace string) (bool, error) {
+	allowed, err := r.can(ctx, "create", "rolebindings", namespace, "")
+	if err != nil || !allowed {
+		return false, err
+	}
+	resource := "clusterroles"
+	if role.IsRole() {
+		resource = "roles"
+	}
+	return r.can(ctx, "bind", resource, namespace, role.Name)
+}
+
+// can checks if the user may use the verb on the resource of the rbac API group, named name or any of them for an
+// empty name, in the namespace or cluster wide for an empty namespace
+func (r *accessReviewer) can(ctx context.Context, verb, resource, namespace, name string) (bool, error) {
+	attributes := authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      verb,
+		Group:     v1.GroupName,
+		Resource:  resource,
+		Name:      name,
+	}
+	if allowed, reviewed := r.decisions[attributes]; reviewed {
+		return allowed, nil
+	}
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range r.user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: attributes.DeepCopy(),
+			User:               r.user.Username,
+			Groups:             r.user.Groups,
+			UID:                r.user.UID,
+			Extra:              extra,
+		},
+	}
+	if err := r.client.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("failed to review access to %s %s: %w", verb, resource, err)
+	}
+	r.decisions[attributes] = review.Status.Allowed
+	return review.Status.Allowed, nil
+}
+
+// namespaceSummary lists the first namespaces and counts the others
+func namespaceSummary(namespaces []string) string {
+	if len(namespaces) <= maxDeniedNamespaces {
+		return strings.Join(namespaces, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(namespaces[:maxDeniedNamespaces], ", "), len(namespaces)-maxDeniedNamespaces)
+}
+
+// SetupWebhookWithManager registers the validating webhook with the Manager.
+func (v *SubjectPermissionValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &managedv1alpha1.SubjectPermission{}).
+		WithValidator(v).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-managed-openshift-io-v1alpha1-clustersubjectpermission,mutating=false,failurePolicy=fail,sideEffects=None,groups=managed.openshift.io,resources=clustersubjectpermissions,verbs=create;update,versions=v1alpha1,name=vclustersubjectpermission.managed.openshift.io,admissionReviewVersions=v1
+
+// ClusterSubjectPermissionValidator validates ClusterSubjectPermissions at admission time.
+// Writing a cluster scoped object already takes cluster wide permissions, so the author is not checked
+type ClusterSubjectPermissionValidator struct {
+	// Client is used to look up the ClusterRoles referenced by the ClusterSubjectPermission
+	Client client.Reader
+	// Config toggles the missing ClusterRole warnings, they are enabled without it
+	Config *operatorconfig.Store
+}
+
+var _ admission.Validator[*managedv1alpha1.ClusterSubjectPermission] = &ClusterSubjectPermissionValidator{}
+
+// ValidateCreate rejects invalid ClusterSubjectPermissions and warns about missing ClusterRoles
+func (v *ClusterSubjectPermissionValidator) ValidateCreate(ctx context.Context, csp *managedv1alpha1.ClusterSubjectPermission) (admission.Warnings, error) {
+	if err := validateSpec("ClusterSubjectPermission", csp.Name, &csp.Spec); err != nil {
+		return nil, err
+	}
+	if !v.Config.Get().Features.MissingClusterRoleWarnings {
+		return nil, nil
+	}
+	return missingClusterRoleWarnings(ctx, v.Client, &csp.Spec), nil
+}
+
+// ValidateUpdate rejects invalid ClusterSubjectPermissions and warns about missing ClusterRoles
+func (v *ClusterSubjectPermissionValidator) ValidateUpdate(ctx context.Context, oldCSP, newCSP *managedv1alpha1.ClusterSubjectPermission) (admission.Warnings, error) {
+	// let objects that are being deleted through, so an invalid spec never blocks finalizer removal
+	if newCSP.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return v.ValidateCreate(ctx, newCSP)
+}
+
+// ValidateDelete allows every deletion
+func (v *ClusterSubjectPermissionValidator) ValidateDelete(ctx context.Context, csp *managedv1alpha1.ClusterSubjectPermission) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// SetupWebhookWithManager registers the validating webhook with the Manager.
+func (v *ClusterSubjectPermissionValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &managedv1alpha1.ClusterSubjectPermission{}).
+		WithValidator(v).
+		Complete()
+}
+
+// validateSpec runs the spec validation shared with the reconciler
+func validateSpec(kind, name string, spec *managedv1alpha1.SubjectPermissionSpec) error {
+	if allErrs := validateSubjectPermissionSpec(spec, field.NewPath("spec")); len(allErrs) != 0 {
+		localmetrics.IncValidationFailures("admission")
+		return k8serr.NewInvalid(managedv1alpha1.GroupVersion.WithKind(kind).GroupKind(), name, allErrs)
+	}
+	return nil
+}
+
+// missingClusterRoleWarnings returns a warning for every referenced ClusterRole that does not exist yet.
+// The bindings are created once the ClusterRole shows up, so this is not a reason to reject the object.
+func missingClusterRoleWarnings(ctx context.Context, c client.Reader, spec *managedv1alpha1.SubjectPermissionSpec) admission.Warnings {
+	var warnings admission.Warnings
+	checked := map[string]bool{}
+	// the ClusterRoles defined by the SubjectPermission are created with it
+	for _, definition := range spec.ClusterRoles {
+		checked[definition.Name] = true
+	}
+	check := func(fldPath *field.Path, clusterRoleName string) {
+		if checked[clusterRoleName] {
+			return
+		}
+		checked[clusterRoleName] = true
+		err := c.Get(ctx, client.ObjectKey{Name: clusterRoleName}, &v1.ClusterRole{})
+		switch {
+		case k8serr.IsNotFound(err):
+			warnings = append(warnings, fmt.Sprintf("%s: ClusterRole %q does not exist yet", fldPath, clusterRoleName))
+		case err != nil:
+			webhookLog.Error(err, "Failed to look up ClusterRole", "clusterRoleName", clusterRoleName)
+		}
+	}
+
+	for i, clusterRoleName := range spec.ClusterPermissions {
+		check(field.NewPath("spec", "clusterPermissions").Index(i), clusterRoleName)
+	}
+	for i, permission := range spec.Permissions {
+		// a Role lives in the matched namespaces, its absence is reported in the status instead
+		if permission.RoleRef != nil {
+			if !permission.RoleRef.IsRole() {
+				check(field.NewPath("spec", "permissions").Index(i).Child("roleRef", "name"), permission.RoleRef.Name)
+			}
+			continue
+		}
+		check(field.NewPath("spec", "permissions").Index(i).Child("clusterRoleName"), permission.ClusterRoleName)
+	}
+	return warnings
+}
@@ -0,0 +1,364 @@
+package subjectpermission_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	"github.com/openshift/rbac-permissions-operator/controllers/subjectpermission"
+	testconst "github.com/openshift/rbac-permissions-operator/pkg/const/test"
+	"github.com/openshift/rbac-permissions-operator/pkg/operatorconfig"
+	clientmocks "github.com/openshift/rbac-permissions-operator/pkg/util/test/generated/mocks/client"
+)
+
+var _ = Describe("SubjectPermission Webhook", func() {
+	var (
+		mockCtrl              *gomock.Controller
+		mockClient            *clientmocks.MockClient
+		validator             *subjectpermission.SubjectPermissionValidator
+		testSubjectPermission v1alpha1.SubjectPermission
+		ctx                   context.Context
+	)
+
+	// expectAccessReviews answers the SubjectAccessReviews of the author with the decision of allowed
+	expectAccessReviews := func(allowed func(attributes *authorizationv1.ResourceAttributes) bool) *gomock.Call {
+		return mockClient.EXPECT().Create(gomock.Any(), gomock.AssignableToTypeOf(&authorizationv1.SubjectAccessReview{})).DoAndReturn(
+			func(ctx context.Context, review *authorizationv1.SubjectAccessReview, co ...client.CreateOption) error {
+				Expect(review.Spec.User).To(Equal("tenant"))
+				review.Status.Allowed = allowed(review.Spec.ResourceAttributes)
+				return nil
+			})
+	}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockClient = clientmocks.NewMockClient(mockCtrl)
+		validator = &subjectpermission.SubjectPermissionValidator{Client: mockClient}
+		testSubjectPermission = testconst.TestSubjectPermission
+		testSubjectPermission.Spec.SubjectKind = "Group"
+		ctx = admission.NewContextWithRequest(testconst.Context, admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: "tenant", Groups: []string{"system:authenticated"}},
+		}})
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	When("The SubjectPermission is valid", func() {
+		It("Admits it without warnings", func() {
+			expectAccessReviews(func(*authorizationv1.ResourceAttributes) bool { return true }).Times(5)
+			mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Times(3).Return(nil)
+			warnings, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	When("The SubjectPermission references a ClusterRole that does not exist", func() {
+		It("Admits it with a warning", func() {
+			notFound := k8serr.NewNotFound(schema.GroupResource{Group: "rbac.authorization.k8s.io", Resource: "clusterroles"}, "testClusterRoleName")
+			mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "exampleClusterRoleName"}, gomock.Any()).Times(1).Return(nil)
+			mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "exampleClusterRoleNameTwo"}, gomock.Any()).Times(1).Return(nil)
+			mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "testClusterRoleName"}, gomock.Any()).Times(1).Return(notFound)
+			expectAccessReviews(func(*authorizationv1.ResourceAttributes) bool { return true }).Times(5)
+			warnings, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring(`spec.permissions[1].clusterRoleName: ClusterRole "testClusterRoleName" does not exist yet`)))
+		})
+	})
+
+	When("The SubjectPermission is invalid", func() {
+		It("Rejects it with field path errors", func() {
+			testSubjectPermission.Spec.SubjectKind = "InvalidKind"
+			testSubjectPermission.Spec.Permissions = []v1alpha1.Permission{
+				{
+					ClusterRoleName:        "test-role",
+					NamespacesAllowedRegex: "[invalid-regex",
+				},
+			}
+			_, err := validator.ValidateUpdate(ctx, &testconst.TestSubjectPermission, &testSubjectPermission)
+			Expect(err).To(HaveOccurred())
+			Expect(k8serr.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.subjectKind"))
+			Expect(err.Error()).To(ContainSubstring("subjectKind must be one of"))
+			Expect(err.Error()).To(ContainSubstring("spec.permissions[0].namespacesAllowedRegex"))
+		})
+	})
+
+	When("A Permission sets both or neither of clusterRoleName and roleRef", func() {
+		It("Rejects it with the path of the role", func() {
+			testSubjectPermission.Spec.Permissions = []v1alpha1.Permission{
+				{ClusterRoleName: "view", RoleRef: &v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindRole, Name: "deployer"}},
+				{NamespacesAllowedRegex: ".*"},
+				{RoleRef: &v1alpha1.PermissionRoleRef{Kind: "Group", Name: "deployer"}},
+			}
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(k8serr.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.permissions[0].roleRef: Forbidden"))
+			Expect(err.Error()).To(ContainSubstring("spec.permissions[1].clusterRoleName: Required"))
+			Expect(err.Error()).To(ContainSubstring("spec.permissions[2].roleRef.kind: Unsupported value"))
+		})
+	})
+
+	When("A Permission binds a Role", func() {
+		It("Admits it without looking up the Role", func() {
+			testSubjectPermission.Spec.Permissions = []v1alpha1.Permission{
+				{RoleRef: &v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindRole, Name: "deployer"}, NamespacesAllowedRegex: ".*"},
+			}
+			expectAccessReviews(func(*authorizationv1.ResourceAttributes) bool { return true }).Times(5)
+			mockClient.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(&rbacv1.ClusterRole{})).Times(2).Return(nil)
+			warnings, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	When("A ServiceAccount subject has no namespace", func() {
+		It("Rejects it with the path of the namespace", func() {
+			testSubjectPermission.Spec.SubjectKind = "ServiceAccount"
+			testSubjectPermission.Spec.SubjectNamespace = ""
+			testSubjectPermission.Spec.Subjects = []rbacv1.Subject{{Kind: "ServiceAccount", Name: "exampleServiceAccount"}}
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.subjectNamespace"))
+			Expect(err.Error()).To(ContainSubstring("spec.subjects[0].namespace"))
+		})
+	})
+
+	When("The SubjectPermission has an invalid namespaceSelector", func() {
+		It("Rejects it with the path of the selector", func() {
+			testSubjectPermission.Spec.Permissions = []v1alpha1.Permission{
+				{
+					ClusterRoleName: "test-role",
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: metav1.LabelSelectorOpIn}},
+					},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.permissions[0].namespaceSelector.matchExpressions[0].values"))
+		})
+	})
+
+	When("The author cannot create the ClusterRoles the SubjectPermission defines", func() {
+		It("Rejects the clusterRoles", func() {
+			testSubjectPermission.Spec.ClusterRoles = []v1alpha1.ManagedClusterRole{{Name: "testClusterRoleName"}}
+			expectAccessReviews(func(attributes *authorizationv1.ResourceAttributes) bool {
+				return attributes.Resource != "clusterroles" || attributes.Verb != "create"
+			}).Times(7)
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(k8serr.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.clusterRoles: Forbidden: tenant cannot create ClusterRoles"))
+		})
+	})
+
+	When("The author cannot escalate to the rules of the ClusterRoles the SubjectPermission defines", func() {
+		It("Rejects the clusterRoles", func() {
+			testSubjectPermission.Spec.ClusterRoles = []v1alpha1.ManagedClusterRole{
+				{Name: "tenant-admin", Rules: []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}}},
+			}
+			expectAccessReviews(func(attributes *authorizationv1.ResourceAttributes) bool {
+				return attributes.Verb != "escalate"
+			}).Times(7)
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(k8serr.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.clusterRoles[0]: Forbidden: tenant cannot escalate ClusterRole tenant-admin"))
+		})
+	})
+
+	When("The author cannot bind a ClusterRole of clusterPermissions", func() {
+		It("Rejects the ClusterRole", func() {
+			expectAccessReviews(func(attributes *authorizationv1.ResourceAttributes) bool {
+				return attributes.Verb != "bind" || attributes.Name != "exampleClusterRoleNameTwo"
+			}).Times(5)
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(k8serr.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.clusterPermissions[1]: Forbidden: tenant cannot bind ClusterRole exampleClusterRoleNameTwo"))
+			Expect(err.Error()).ToNot(ContainSubstring("spec.clusterPermissions[0]"))
+		})
+	})
+
+	When("The SubjectPermission defines the same ClusterRole twice", func() {
+		It("Rejects it with the path of the duplicate", func() {
+			testSubjectPermission.Spec.ClusterRoles = []v1alpha1.ManagedClusterRole{{Name: "tenant-deployer"}, {Name: "tenant-deployer"}, {Name: "a/b"}}
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(k8serr.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.clusterRoles[1].name: Duplicate value"))
+			Expect(err.Error()).To(ContainSubstring("spec.clusterRoles[2].name: Invalid value"))
+		})
+	})
+
+	When("The SubjectPermission sets both expiresAt and duration", func() {
+		It("Rejects it with the path of the duration", func() {
+			expiresAt := metav1.NewTime(time.Now().Add(time.Hour))
+			testSubjectPermission.Spec.ExpiresAt = &expiresAt
+			testSubjectPermission.Spec.Duration = &metav1.Duration{Duration: -time.Hour}
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(k8serr.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.duration: Forbidden: duration cannot be set together with expiresAt"))
+			Expect(err.Error()).To(ContainSubstring("spec.duration: Invalid value: \"-1h0m0s\": duration must be positive"))
+		})
+	})
+
+	When("The author cannot create ClusterRoleBindings", func() {
+		It("Rejects the clusterPermissions", func() {
+			expectAccessReviews(func(attributes *authorizationv1.ResourceAttributes) bool {
+				return attributes.Resource != "clusterrolebindings"
+			}).Times(5)
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(k8serr.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.clusterPermissions"))
+			Expect(err.Error()).To(ContainSubstring("ClusterSubjectPermission"))
+		})
+	})
+
+	When("The author review and the warnings are disabled in the operator configuration", func() {
+		It("Admits it without reviewing the author or looking up the ClusterRoles", func() {
+			validator.Config = operatorconfig.NewStore()
+			_, err := validator.Config.Update(&corev1.ConfigMap{Data: map[string]string{
+				operatorconfig.FeaturesKey: "AuthorReview=false, MissingClusterRoleWarnings=false",
+			}})
+			Expect(err).ToNot(HaveOccurred())
+			warnings, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	When("The author can only create RoleBindings in some namespaces", func() {
+		BeforeEach(func() {
+			testSubjectPermission.Spec.ClusterPermissions = nil
+			testSubjectPermission.Spec.Permissions = []v1alpha1.Permission{
+				{
+					ClusterRoleName:        "admin",
+					NamespacesAllowedRegex: "^(team-a|team-b)$",
+				},
+			}
+		})
+
+		It("Rejects a Permission listing the other namespaces, whether they exist or not", func() {
+			expectAccessReviews(func(attributes *authorizationv1.ResourceAttributes) bool {
+				return attributes.Namespace == "team-a"
+			}).Times(4)
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(k8serr.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.permissions[0]: Forbidden: tenant cannot bind ClusterRole admin in namespaces team-b"))
+		})
+
+		It("Rejects a Permission matching the namespaces created later", func() {
+			testSubjectPermission.Spec.Permissions[0].NamespacesAllowedRegex = "^team-"
+			expectAccessReviews(func(attributes *authorizationv1.ResourceAttributes) bool {
+				return attributes.Namespace == "team-a"
+			}).Times(1)
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(k8serr.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.permissions[0].namespacesAllowedRegex: Forbidden: tenant cannot bind ClusterRole admin in every namespace"))
+		})
+
+		It("Rejects a Permission binding a Role the author cannot bind", func() {
+			testSubjectPermission.Spec.Permissions[0] = v1alpha1.Permission{
+				RoleRef:                &v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindRole, Name: "deployer"},
+				NamespacesAllowedRegex: "^team-a$",
+			}
+			expectAccessReviews(func(attributes *authorizationv1.ResourceAttributes) bool {
+				if attributes.Verb == "bind" {
+					Expect(attributes.Resource).To(Equal("roles"))
+					Expect(attributes.Name).To(Equal("deployer"))
+					return false
+				}
+				return attributes.Namespace == "team-a"
+			}).Times(3)
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(k8serr.IsForbidden(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.permissions[0]: Forbidden: tenant cannot bind Role deployer in namespaces team-a"))
+		})
+
+		It("Admits a Permission limited to its namespaces", func() {
+			testSubjectPermission.Spec.Permissions[0].NamespacesAllowedRegex = "^team-a$"
+			expectAccessReviews(func(attributes *authorizationv1.ResourceAttributes) bool {
+				return attributes.Namespace == "team-a"
+			}).Times(3)
+			mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "admin"}, gomock.Any()).Return(nil)
+			_, err := validator.ValidateCreate(ctx, &testSubjectPermission)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Does not review the author again when the spec is unchanged", func() {
+			updated := testSubjectPermission.DeepCopy()
+			updated.Finalizers = []string{"subjectpermission.managed.openshift.io/finalizer"}
+			mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "admin"}, gomock.Any()).Return(nil)
+			_, err := validator.ValidateUpdate(ctx, &testSubjectPermission, updated)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	When("An invalid SubjectPermission is being deleted", func() {
+		It("Admits the update so the finalizer can be removed", func() {
+			testSubjectPermission.Spec.SubjectName = ""
+			now := metav1.Now()
+			testSubjectPermission.DeletionTimestamp = &now
+			_, err := validator.ValidateUpdate(ctx, &testconst.TestSubjectPermission, &testSubjectPermission)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ClusterSubjectPermission Webhook", func() {
+	var (
+		mockCtrl   *gomock.Controller
+		mockClient *clientmocks.MockClient
+		validator  *subjectpermission.ClusterSubjectPermissionValidator
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockClient = clientmocks.NewMockClient(mockCtrl)
+		validator = &subjectpermission.ClusterSubjectPermissionValidator{Client: mockClient}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Rejects an invalid ClusterSubjectPermission", func() {
+		csp := &v1alpha1.ClusterSubjectPermission{
+			ObjectMeta: metav1.ObjectMeta{Name: "dedicated-admins"},
+			Spec:       v1alpha1.SubjectPermissionSpec{SubjectKind: "Group"},
+		}
+		_, err := validator.ValidateCreate(testconst.Context, csp)
+		Expect(k8serr.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("ClusterSubjectPermission"))
+		Expect(err.Error()).To(ContainSubstring("spec.subjectName"))
+	})
+
+	It("Admits a valid ClusterSubjectPermission without reviewing the author", func() {
+		csp := &v1alpha1.ClusterSubjectPermission{
+			ObjectMeta: metav1.ObjectMeta{Name: "dedicated-admins"},
+			Spec: v1alpha1.SubjectPermissionSpec{
+				SubjectKind:        "Group",
+				SubjectName:        "dedicated-admins",
+				ClusterPermissions: []string{"dedicated-admins-cluster"},
+			},
+		}
+		mockClient.EXPECT().Get(gomock.Any(), client.ObjectKey{Name: "dedicated-admins-cluster"}, gomock.Any()).Return(nil)
+		warnings, err := validator.ValidateCreate(testconst.Context, csp)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+})
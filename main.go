@@ -35,10 +35,14 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -47,8 +51,10 @@ import (
 
 	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
 	nscontrollers "github.com/openshift/rbac-permissions-operator/controllers/namespace"
+	opconfigcontrollers "github.com/openshift/rbac-permissions-operator/controllers/operatorconfig"
 	controllers "github.com/openshift/rbac-permissions-operator/controllers/subjectpermission"
 	"github.com/openshift/rbac-permissions-operator/pkg/k8sutil"
+	"github.com/openshift/rbac-permissions-operator/pkg/operatorconfig"
 
 	monitorv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	//+kubebuilder:scaffold:imports
@@ -60,7 +66,6 @@ var (
 )
 
 var (
-	osdMetricsPort = "8181"
 	osdMetricsPath = "/metrics"
 )
 
@@ -101,6 +106,22 @@ func main() {
 		os.Exit(1)
 	}
 
+	// SubjectPermissions are only watched in the namespaces of WATCH_NAMESPACE when it is set, so several instances
+	// of the operator can own separate sets of them. Namespaces and bindings are always watched cluster wide
+	watchNamespaces := k8sutil.GetWatchNamespaces()
+	cacheByObject := map[client.Object]cache.ByObject{
+		// only the operator ConfigMap is read, there is no need to cache the others
+		&corev1.ConfigMap{}: {Namespaces: map[string]cache.Config{operatorNS: {}}},
+	}
+	if len(watchNamespaces) != 0 {
+		setupLog.Info("Watching SubjectPermissions in namespaces", "namespaces", watchNamespaces)
+		namespaces := map[string]cache.Config{}
+		for _, ns := range watchNamespaces {
+			namespaces[ns] = cache.Config{}
+		}
+		cacheByObject[&managedv1alpha1.SubjectPermission{}] = cache.ByObject{Namespaces: namespaces}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 		Metrics: server.Options{
@@ -109,17 +130,30 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "bd14765d.openshift.io",
+		Cache: cache.Options{
+			ByObject: cacheByObject,
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	// The operator configuration is read from its ConfigMap before the controllers are set up, as the concurrency
+	// and the metrics port are only read at startup. It is reloaded whenever the ConfigMap changes afterwards.
+	// A ConfigMap that cannot be loaded starts the operator with the defaults, like an invalid ConfigMap keeps the
+	// configuration in effect at runtime, and is retried by the operatorconfig controller until it is fixed.
+	operatorConfig := operatorconfig.NewStore()
+	configMapKey := types.NamespacedName{Namespace: operatorNS, Name: config.OperatorConfigMapName}
+	if _, err = operatorConfig.Load(context.TODO(), mgr.GetAPIReader(), configMapKey); err != nil {
+		setupLog.Error(err, "unable to load the operator configuration, starting with the default configuration")
+	}
+
 	// Ensure lock for leader election
 	_, err = k8sutil.GetOperatorNamespace()
 	switch err {
 	case nil:
-		err = leader.Become(context.TODO(), "rbac-permissions-operator-lock")
+		err = leader.Become(context.TODO(), config.OperatorLockName)
 		if err != nil {
 			setupLog.Error(err, "failed to create leader lock")
 			os.Exit(1)
@@ -132,29 +166,81 @@ func main() {
 	}
 
 	// Add controllers to manager
+	if err = (&opconfigcontrollers.ConfigMapReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Config:    operatorConfig,
+		Namespace: operatorNS,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OperatorConfig")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.SubjectPermissionReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Config:          operatorConfig,
+		Recorder:        mgr.GetEventRecorder("subjectpermission-controller"),
+		WatchNamespaces: watchNamespaces,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SubjectPermission")
 		os.Exit(1)
 	}
 
+	// ClusterSubjectPermissions are cluster scoped, they are left to the instance watching every namespace
+	clusterScoped := len(watchNamespaces) == 0
+	if clusterScoped {
+		if err = (&controllers.ClusterSubjectPermissionReconciler{
+			SubjectPermissionReconciler: controllers.SubjectPermissionReconciler{
+				Client:   mgr.GetClient(),
+				Scheme:   mgr.GetScheme(),
+				Config:   operatorConfig,
+				Recorder: mgr.GetEventRecorder("clustersubjectpermission-controller"),
+			},
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterSubjectPermission")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&nscontrollers.NamespaceReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:                          mgr.GetClient(),
+		Scheme:                          mgr.GetScheme(),
+		Config:                          operatorConfig,
+		Recorder:                        mgr.GetEventRecorder("namespace-controller"),
+		IgnoreClusterSubjectPermissions: !clusterScoped,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Namespace")
 		os.Exit(1)
 	}
 
+	// The validating webhook needs a serving certificate, set ENABLE_WEBHOOKS=false when running locally
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&controllers.SubjectPermissionValidator{
+			Client: mgr.GetClient(),
+			Config: operatorConfig,
+		}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SubjectPermission")
+			os.Exit(1)
+		}
+		if clusterScoped {
+			if err = (&controllers.ClusterSubjectPermissionValidator{
+				Client: mgr.GetClient(),
+				Config: operatorConfig,
+			}).SetupWebhookWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create webhook", "webhook", "ClusterSubjectPermission")
+				os.Exit(1)
+			}
+		}
+	}
+
 	if err = monitorv1.AddToScheme(clientgoscheme.Scheme); err != nil {
 		setupLog.Error(err, "unable to add monitoringv1 scheme")
 		os.Exit(1)
 	}
 
 	metricsServer := osdmetrics.NewBuilder(operatorNS, config.OperatorName).
-		WithPort(osdMetricsPort).
+		WithPort(operatorConfig.Get().MetricsPort).
 		WithPath(osdMetricsPath).
 		WithCollectors(metrics.MetricsList).
 		WithServiceMonitor().
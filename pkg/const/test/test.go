@@ -43,13 +43,13 @@ var (
 			},
 		},
 		Status: v1alpha1.SubjectPermissionStatus{
-			Conditions: []v1alpha1.Condition{
+			Conditions: []metav1.Condition{
 				{
+					Type:               v1alpha1.ConditionReady,
 					LastTransitionTime: metav1.Now(),
-					ClusterRoleNames:   []string{"exampleClusterRoleName"},
 					Message:            "exampleMessage",
-					Status:             true,
-					State:              "exampleState",
+					Status:             metav1.ConditionTrue,
+					Reason:             v1alpha1.ReasonReconciled,
 				},
 			},
 		},
@@ -130,13 +130,14 @@ var (
 
 	TestRoleBinding = &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "examplePermissionClusterRoleName-exampleGroupName",
+			Name:      "examplePermissionClusterRoleName-group-exampleGroupName-a83c5c29d7",
 			Namespace: "examplenamespace",
 		},
 		Subjects: []rbacv1.Subject{
 			{
-				Kind: "Group",
-				Name: "exampleGroupName",
+				Kind:     "Group",
+				APIGroup: "rbac.authorization.k8s.io",
+				Name:     "exampleGroupName",
 			},
 		},
 		RoleRef: rbacv1.RoleRef{
@@ -147,7 +148,7 @@ var (
 
 	TestRoleBindingSA = &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "examplePermissionClusterRoleName-exampleGroupName",
+			Name:      "examplePermissionClusterRoleName-serviceaccount-exampleGroupNamespace-exampleGroupName-e335bb2cd8",
 			Namespace: "examplenamespace",
 		},
 		Subjects: []rbacv1.Subject{
@@ -185,25 +186,21 @@ var (
 		},
 	}
 
-	TestConditions = []v1alpha1.Condition{
+	TestConditions = []metav1.Condition{
 		{
-			ClusterRoleNames: []string{"exampleClusterRoleName"},
-			Message:          "exampleMessage",
-			Status:           true,
-			State:            "exampleState",
+			Type:    v1alpha1.ConditionReady,
+			Message: "exampleMessage",
+			Status:  metav1.ConditionTrue,
+			Reason:  v1alpha1.ReasonReconciled,
 		},
 		{
-			ClusterRoleNames: []string{"testClusterRoleName"},
-			Message:          "testMessage",
-			Status:           false,
-			State:            "testState",
+			Type:    v1alpha1.ConditionDegraded,
+			Message: "testMessage",
+			Status:  metav1.ConditionFalse,
+			Reason:  v1alpha1.ReasonReconciled,
 		},
 	}
 
-	TestSubjectPermissionState v1alpha1.SubjectPermissionState = "testState"
-
-	TestSubjectPermissionType v1alpha1.SubjectPermissionType = "testType"
-
 	TestNamespaceName = types.NamespacedName{
 		Name:      "test",
 		Namespace: "test-namespace",
@@ -0,0 +1,159 @@
+package util
+
+import (
+	"context"
+
+	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// EnsureClusterRoleBinding creates the desired ClusterRoleBinding, or restores the subjects, roleRef
+// and ownership metadata of the existing one when they drifted from the desired state.
+// existing is the ClusterRoleBinding with the same name found on the cluster, or nil.
+func EnsureClusterRoleBinding(ctx context.Context, c client.Client, desired, existing *v1.ClusterRoleBinding) (ctrlutil.OperationResult, error) {
+	if existing == nil {
+		return createBinding(ctx, c, desired)
+	}
+	if existing.RoleRef != desired.RoleRef {
+		return recreateBinding(ctx, c, existing, desired)
+	}
+	if equality.Semantic.DeepEqual(existing.Subjects, desired.Subjects) && !metadataDrifted(existing, desired) {
+		return ctrlutil.OperationResultNone, nil
+	}
+	patch := client.MergeFrom(existing.DeepCopy())
+	existing.Subjects = desired.Subjects
+	restoreMetadata(existing, desired)
+	if err := c.Patch(ctx, existing, patch); err != nil {
+		return ctrlutil.OperationResultNone, err
+	}
+	return ctrlutil.OperationResultUpdated, nil
+}
+
+// EnsureRoleBinding creates the desired RoleBinding, or restores the subjects, roleRef
+// and ownership metadata of the existing one when they drifted from the desired state.
+// existing is the RoleBinding with the same name and namespace found on the cluster, or nil.
+func EnsureRoleBinding(ctx context.Context, c client.Client, desired, existing *v1.RoleBinding) (ctrlutil.OperationResult, error) {
+	if existing == nil {
+		return createBinding(ctx, c, desired)
+	}
+	if existing.RoleRef != desired.RoleRef {
+		return recreateBinding(ctx, c, existing, desired)
+	}
+	if equality.Semantic.DeepEqual(existing.Subjects, desired.Subjects) && !metadataDrifted(existing, desired) {
+		return ctrlutil.OperationResultNone, nil
+	}
+	patch := client.MergeFrom(existing.DeepCopy())
+	existing.Subjects = desired.Subjects
+	restoreMetadata(existing, desired)
+	if err := c.Patch(ctx, existing, patch); err != nil {
+		return ctrlutil.OperationResultNone, err
+	}
+	return ctrlutil.OperationResultUpdated, nil
+}
+
+// PlanClusterRoleBinding returns the change EnsureClusterRoleBinding would make, without making it
+func PlanClusterRoleBinding(desired, existing *v1.ClusterRoleBinding) ctrlutil.OperationResult {
+	if existing == nil {
+		return ctrlutil.OperationResultCreated
+	}
+	return planUpdate(existing, desired, existing.RoleRef, desired.RoleRef, existing.Subjects, desired.Subjects)
+}
+
+// PlanRoleBinding returns the change EnsureRoleBinding would make, without making it
+func PlanRoleBinding(desired, existing *v1.RoleBinding) ctrlutil.OperationResult {
+	if existing == nil {
+		return ctrlutil.OperationResultCreated
+	}
+	return planUpdate(existing, desired, existing.RoleRef, desired.RoleRef, existing.Subjects, desired.Subjects)
+}
+
+// planUpdate checks if an existing binding drifted from the desired state and would be restored
+func planUpdate(existing, desired metav1.Object, existingRoleRef, desiredRoleRef v1.RoleRef, existingSubjects, desiredSubjects []v1.Subject) ctrlutil.OperationResult {
+	if existingRoleRef != desiredRoleRef || !equality.Semantic.DeepEqual(existingSubjects, desiredSubjects) || metadataDrifted(existing, desired) {
+		return ctrlutil.OperationResultUpdated
+	}
+	return ctrlutil.OperationResultNone
+}
+
+// FindClusterRoleBinding returns the ClusterRoleBinding with the given name from the list, or nil
+func FindClusterRoleBinding(name string, clusterRoleBindingList *v1.ClusterRoleBindingList) *v1.ClusterRoleBinding {
+	for i := range clusterRoleBindingList.Items {
+		if clusterRoleBindingList.Items[i].Name == name {
+			return &clusterRoleBindingList.Items[i]
+		}
+	}
+	return nil
+}
+
+// FindRoleBinding returns the RoleBinding with the given namespace and name from the list, or nil
+func FindRoleBinding(namespace, name string, roleBindingList *v1.RoleBindingList) *v1.RoleBinding {
+	for i := range roleBindingList.Items {
+		if roleBindingList.Items[i].Namespace == namespace && roleBindingList.Items[i].Name == name {
+			return &roleBindingList.Items[i]
+		}
+	}
+	return nil
+}
+
+// createBinding creates the binding, a binding that already exists but is not in the cache yet
+// is left alone: the watch on bindings triggers another reconcile once the cache caught up
+func createBinding(ctx context.Context, c client.Client, desired client.Object) (ctrlutil.OperationResult, error) {
+	if err := c.Create(ctx, desired); err != nil {
+		if k8serr.IsAlreadyExists(err) {
+			return ctrlutil.OperationResultNone, nil
+		}
+		return ctrlutil.OperationResultNone, err
+	}
+	return ctrlutil.OperationResultCreated, nil
+}
+
+// recreateBinding replaces a binding whose roleRef changed, as roleRef is immutable
+func recreateBinding(ctx context.Context, c client.Client, existing, desired client.Object) (ctrlutil.OperationResult, error) {
+	if err := c.Delete(ctx, existing); err != nil && !k8serr.IsNotFound(err) {
+		return ctrlutil.OperationResultNone, err
+	}
+	if err := c.Create(ctx, desired); err != nil {
+		return ctrlutil.OperationResultNone, err
+	}
+	return ctrlutil.OperationResultUpdated, nil
+}
+
+// metadataDrifted checks if the existing binding lost any of the desired ownership labels or annotations
+func metadataDrifted(existing, desired metav1.Object) bool {
+	return !containsAll(existing.GetLabels(), desired.GetLabels()) || !containsAll(existing.GetAnnotations(), desired.GetAnnotations())
+}
+
+// restoreMetadata adds the desired labels and annotations to the existing binding
+func restoreMetadata(existing, desired metav1.Object) {
+	labels := existing.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for key, value := range desired.GetLabels() {
+		labels[key] = value
+	}
+	existing.SetLabels(labels)
+
+	annotations := existing.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for key, value := range desired.GetAnnotations() {
+		annotations[key] = value
+	}
+	existing.SetAnnotations(annotations)
+}
+
+// containsAll checks if every key of want is set to the same value in got
+func containsAll(got, want map[string]string) bool {
+	for key, value := range want {
+		if got[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package util
+
+import (
+	"context"
+
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// NewManagedClusterRole returns the ClusterRole defined in the spec of the SubjectPermission,
+// labeled and annotated as owned by it
+func NewManagedClusterRole(subjectPermission managedv1alpha1.SubjectPermissionObject, definition managedv1alpha1.ManagedClusterRole) *v1.ClusterRole {
+	clusterRole := &v1.ClusterRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: definition.Name,
+		},
+		Rules:           definition.Rules,
+		AggregationRule: definition.AggregationRule,
+	}
+	SetOwnershipMetadata(clusterRole, subjectPermission, definition.Name)
+	return clusterRole
+}
+
+// EnsureClusterRole creates the desired ClusterRole, or restores the rules, aggregation rule and ownership metadata
+// of the existing one when they drifted from the desired state. The rules of an aggregated ClusterRole are filled in
+// by the cluster and left alone. existing is the ClusterRole with the same name found on the cluster, or nil.
+func EnsureClusterRole(ctx context.Context, c client.Client, desired, existing *v1.ClusterRole) (ctrlutil.OperationResult, error) {
+	if existing == nil {
+		if err := c.Create(ctx, desired); err != nil {
+			// not in the cache yet, the watch on ClusterRoles triggers another reconcile
+			if k8serr.IsAlreadyExists(err) {
+				return ctrlutil.OperationResultNone, nil
+			}
+			return ctrlutil.OperationResultNone, err
+		}
+		return ctrlutil.OperationResultCreated, nil
+	}
+	if PlanClusterRole(desired, existing) == ctrlutil.OperationResultNone {
+		return ctrlutil.OperationResultNone, nil
+	}
+	patch := client.MergeFrom(existing.DeepCopy())
+	existing.AggregationRule = desired.AggregationRule
+	if desired.AggregationRule == nil {
+		existing.Rules = desired.Rules
+	}
+	restoreMetadata(existing, desired)
+	if err := c.Patch(ctx, existing, patch); err != nil {
+		return ctrlutil.OperationResultNone, err
+	}
+	return ctrlutil.OperationResultUpdated, nil
+}
+
+// PlanClusterRole returns the change EnsureClusterRole would make, without making it
+func PlanClusterRole(desired, existing *v1.ClusterRole) ctrlutil.OperationResult {
+	if existing == nil {
+		return ctrlutil.OperationResultCreated
+	}
+	if !equality.Semantic.DeepEqual(existing.AggregationRule, desired.AggregationRule) || metadataDrifted(existing, desired) {
+		return ctrlutil.OperationResultUpdated
+	}
+	if desired.AggregationRule == nil && !equality.Semantic.DeepEqual(existing.Rules, desired.Rules) {
+		return ctrlutil.OperationResultUpdated
+	}
+	return ctrlutil.OperationResultNone
+}
+
+// FindClusterRole returns the ClusterRole with the given name from the list, or nil
+func FindClusterRole(name string, clusterRoleList *v1.ClusterRoleList) *v1.ClusterRole {
+	for i := range clusterRoleList.Items {
+		if clusterRoleList.Items[i].Name == name {
+			return &clusterRoleList.Items[i]
+		}
+	}
+	return nil
+}
@@ -1,32 +1,35 @@
 package util
 
 import (
-	"regexp"
-
 	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // PopulateCrPermissionClusterRoleNames to see if clusterRoleName exists in permission
 // returns list of ClusterRoleNames in permissions that do not exist
-func PopulateCrPermissionClusterRoleNames(subjectPermission *managedv1alpha1.SubjectPermission, clusterRoleList *v1.ClusterRoleList) []string {
+func PopulateCrPermissionClusterRoleNames(subjectPermission managedv1alpha1.SubjectPermissionObject, clusterRoleList *v1.ClusterRoleList) []string {
 	//permission ClusterRoleName
-	permissions := subjectPermission.Spec.Permissions
+	permissions := subjectPermission.GetSpec().Permissions
 
 	var permissionClusterRoleNames []string
 	var found bool
 
 	for _, i := range permissions {
+		// the Roles of a Permission are looked up in each allowed namespace instead
+		if i.Role().IsRole() {
+			continue
+		}
 		found = false
 		for _, a := range clusterRoleList.Items {
-			if i.ClusterRoleName == a.Name {
+			if i.Role().Name == a.Name {
 				found = true
 			}
 		}
 		if !found {
-			permissionClusterRoleNames = append(permissionClusterRoleNames, i.ClusterRoleName)
+			permissionClusterRoleNames = append(permissionClusterRoleNames, i.Role().Name)
 		}
 	}
 
@@ -45,135 +48,98 @@ func PopulateCrPermissionClusterRoleNames(subjectPermission *managedv1alpha1.Sub
 	return result
 }
 
-// GenerateSafeList by 1st checking allow regex then check denied regex
-func GenerateSafeList(allowedRegex string, deniedRegex string, nsList *corev1.NamespaceList) []string {
-	safeList := allowedNamespacesList(allowedRegex, nsList)
-
-	updatedSafeList := safeListAfterDeniedRegex(deniedRegex, safeList)
-
-	return updatedSafeList
-
+// GenerateSafeList returns the namespaces matching allowedRegex and not matching deniedRegex,
+// without the namespaces protected by the grant policy
+func GenerateSafeList(allowedRegex string, deniedRegex string, nsList *corev1.NamespaceList, policy *GrantPolicy) ([]string, error) {
+	return GenerateSafeListForPermission(managedv1alpha1.Permission{
+		NamespacesAllowedRegex: allowedRegex,
+		NamespacesDeniedRegex:  deniedRegex,
+	}, nsList, policy)
 }
 
-// allowedNamespacesList 1st pass - allowedRegex
-func allowedNamespacesList(allowedRegex string, nsList *corev1.NamespaceList) []string {
-	var matches []string
-
-	// for every namespace on the cluster
-	// check that against the allowedRegex in Permission
-	for _, namespace := range nsList.Items {
-		rp := regexp.MustCompile(allowedRegex)
-
-		// if namespace on cluster matches with regex, append them to slice
-		found := rp.MatchString(namespace.Name)
-		if found {
-			matches = append(matches, namespace.Name)
-		}
+// GenerateSafeListForPermission returns the namespaces the Permission applies to.
+// A namespace is allowed when it matches NamespacesAllowedRegex and NamespaceSelector,
+// unless it matches NamespacesDeniedRegex or NamespaceDenySelector. Unset selectors don't restrict the result.
+// The namespaces protected by the grant policy are always excluded afterwards, a nil policy protects none.
+// An error is returned when the regular expressions or selectors of the Permission are invalid
+func GenerateSafeListForPermission(permission managedv1alpha1.Permission, nsList *corev1.NamespaceList, policy *GrantPolicy) ([]string, error) {
+	matcher, err := NewPermissionMatcher(permission)
+	if err != nil {
+		return nil, err
 	}
-
-	return matches
+	safeList, _ := matcher.SafeList(nsList, policy)
+	return safeList, nil
 }
 
-// safeListAfterDeniedRegex 2nd pass - deniedRegex
-func safeListAfterDeniedRegex(namespacesDeniedRegex string, safeList []string) []string {
-	if namespacesDeniedRegex == "" {
-		return safeList
-	}
-	var updatedSafeList []string
-
-	// for every namespace on SafeList
-	// check that against deniedRegex
-	for _, namespace := range safeList {
-		rp := regexp.MustCompile(namespacesDeniedRegex)
-
-		found := rp.MatchString(namespace)
-		// if it does not match then append
-		if !found {
-			updatedSafeList = append(updatedSafeList, namespace)
-		}
-	}
-	return updatedSafeList
-
+// NewRoleBindingForClusterRole creates and returns valid RoleBinding
+func NewRoleBindingForClusterRole(owner managedv1alpha1.SubjectPermissionObject, clusterRoleName, subjectName, subjectNamespace, subjectKind, namespace string) *v1.RoleBinding {
+	return NewRoleBinding(owner, managedv1alpha1.PermissionRoleRef{Kind: managedv1alpha1.RoleKindClusterRole, Name: clusterRoleName}, subjectName, subjectNamespace, subjectKind, namespace)
 }
 
-// NewRoleBindingForClusterRole creates and returns valid RoleBinding
-func NewRoleBindingForClusterRole(clusterRoleName, subjectName, subjectNamespace, subjectKind, namespace string) *v1.RoleBinding {
-	roleBinding := &v1.RoleBinding{
+// NewRoleBinding creates and returns a RoleBinding granting the ClusterRole or the Role of a Permission in the namespace,
+// named after the SubjectPermission owner
+func NewRoleBinding(owner managedv1alpha1.SubjectPermissionObject, role managedv1alpha1.PermissionRoleRef, subjectName, subjectNamespace, subjectKind, namespace string) *v1.RoleBinding {
+	return &v1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      clusterRoleName + "-" + subjectName,
+			Name:      RoleBindingName(owner, role, subjectKind, subjectNamespace, subjectName),
 			Namespace: namespace,
 		},
 		Subjects: []v1.Subject{
-			{
-				Kind: subjectKind,
-				Name: subjectName,
-			},
+			NewSubject(subjectKind, subjectName, subjectNamespace),
 		},
 		RoleRef: v1.RoleRef{
-			Kind: "ClusterRole",
-			Name: clusterRoleName,
+			Kind: role.Kind,
+			Name: role.Name,
 		},
 	}
-
-	if len(subjectNamespace) > 0 {
-		roleBinding.Subjects[0].Namespace = subjectNamespace
-	}
-
-	return roleBinding
 }
 
-// UpdateCondition of SubjectPermission
-func UpdateCondition(conditions []managedv1alpha1.Condition, message string, clusterRoleNames []string, status bool, state managedv1alpha1.SubjectPermissionState, conditionType managedv1alpha1.SubjectPermissionType) []managedv1alpha1.Condition {
-	now := metav1.Now()
-
-	existingCondition := FindRbacCondition(conditions, conditionType)
-
-	// create a map of all unique elements in clusterRoleNames slice
-	encountered := map[string]bool{}
-	for v := range clusterRoleNames {
-		encountered[clusterRoleNames[v]] = true
+// UpdateCondition sets the condition of the given type on the SubjectPermission, recording the generation of the
+// spec it was observed for. LastTransitionTime only changes when the status of the condition changes.
+// Conditions written by earlier versions of the operator are removed. Returns true if the conditions changed
+func UpdateCondition(sp managedv1alpha1.SubjectPermissionObject, conditionType string, status metav1.ConditionStatus, reason, message string) bool {
+	changed := meta.SetStatusCondition(&sp.GetStatus().Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: sp.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+	for _, legacyType := range []string{managedv1alpha1.LegacyClusterRoleBindingCreated, managedv1alpha1.LegacyRoleBindingCreated} {
+		if meta.RemoveStatusCondition(&sp.GetStatus().Conditions, legacyType) {
+			changed = true
+		}
 	}
+	sp.GetStatus().ObservedGeneration = sp.GetGeneration()
+	return changed
+}
 
-	// place all keys from map into result slice
-	// this prevents the duplication of clusterRoleNames
-	result := []string{}
-	for key := range encountered {
-		result = append(result, key)
-	}
+// MarkReady marks every binding of the SubjectPermission as in place
+func MarkReady(sp managedv1alpha1.SubjectPermissionObject, message string) {
+	UpdateCondition(sp, managedv1alpha1.ConditionReady, metav1.ConditionTrue, managedv1alpha1.ReasonReconciled, message)
+	UpdateCondition(sp, managedv1alpha1.ConditionDegraded, metav1.ConditionFalse, managedv1alpha1.ReasonReconciled, message)
+	UpdateCondition(sp, managedv1alpha1.ConditionProgressing, metav1.ConditionFalse, managedv1alpha1.ReasonReconciled, message)
+}
 
-	if existingCondition == nil {
-		conditions = append(
-			conditions, managedv1alpha1.Condition{
-				LastTransitionTime: now,
-				ClusterRoleNames:   result,
-				Message:            message,
-				Status:             status,
-				State:              state,
-				Type:               conditionType,
-			},
-		)
-	} else {
-		if existingCondition.Status != status {
-			existingCondition.LastTransitionTime = now
-		}
-		existingCondition.Message = message
-		existingCondition.ClusterRoleNames = result
-		existingCondition.Status = status
-		existingCondition.State = state
-	}
+// MarkDegraded marks the SubjectPermission as failing to reconcile until the reported problem is fixed
+func MarkDegraded(sp managedv1alpha1.SubjectPermissionObject, reason, message string) {
+	UpdateCondition(sp, managedv1alpha1.ConditionReady, metav1.ConditionFalse, reason, message)
+	UpdateCondition(sp, managedv1alpha1.ConditionDegraded, metav1.ConditionTrue, reason, message)
+	UpdateCondition(sp, managedv1alpha1.ConditionProgressing, metav1.ConditionFalse, reason, message)
+}
 
-	return conditions
+// MarkDryRun marks the SubjectPermission as not applied, as it is in DryRun mode
+func MarkDryRun(sp managedv1alpha1.SubjectPermissionObject, message string) {
+	UpdateCondition(sp, managedv1alpha1.ConditionReady, metav1.ConditionFalse, managedv1alpha1.ReasonDryRun, message)
+	UpdateCondition(sp, managedv1alpha1.ConditionDegraded, metav1.ConditionFalse, managedv1alpha1.ReasonDryRun, message)
+	UpdateCondition(sp, managedv1alpha1.ConditionProgressing, metav1.ConditionFalse, managedv1alpha1.ReasonDryRun, message)
 }
 
-// FindRbacCondition finds in the condition that has the specified condition type in the given list
-// if none exists, then returns nil
-func FindRbacCondition(conditions []managedv1alpha1.Condition, conditionType managedv1alpha1.SubjectPermissionType) *managedv1alpha1.Condition {
-	for i, condition := range conditions {
-		if condition.Type == conditionType {
-			return &conditions[i]
-		}
-	}
-	return nil
+// MarkExpired marks the SubjectPermission as expired, its bindings are revoked until the expiration is extended
+func MarkExpired(sp managedv1alpha1.SubjectPermissionObject, message string) {
+	UpdateCondition(sp, managedv1alpha1.ConditionReady, metav1.ConditionFalse, managedv1alpha1.ReasonExpired, message)
+	UpdateCondition(sp, managedv1alpha1.ConditionDegraded, metav1.ConditionFalse, managedv1alpha1.ReasonExpired, message)
+	UpdateCondition(sp, managedv1alpha1.ConditionProgressing, metav1.ConditionFalse, managedv1alpha1.ReasonExpired, message)
 }
 
 // check if namespace exist and NamespacePhase is non terminating
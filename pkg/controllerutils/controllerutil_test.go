@@ -1,14 +1,27 @@
 package util
 
 import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/openshift/rbac-permissions-operator/api/v1alpha1"
 	testconst "github.com/openshift/rbac-permissions-operator/pkg/const/test"
+	clientmocks "github.com/openshift/rbac-permissions-operator/pkg/util/test/generated/mocks/client"
 )
 
 var _ = Describe("Controller Utils Tests", func() {
@@ -16,7 +29,6 @@ var _ = Describe("Controller Utils Tests", func() {
 	var (
 		mockCtrl            *gomock.Controller
 		TestDeniedList      string
-		TestConditions      []v1alpha1.Condition
 		testClusterRoleList *rbacv1.ClusterRoleList
 	)
 
@@ -63,90 +75,832 @@ var _ = Describe("Controller Utils Tests", func() {
 			Expect(crname).To(ContainElement(ContainSubstring("exampleClusterRoleName")))
 			Expect(crname).ToNot(ContainElement(ContainSubstring("testClusterRoleName")))
 		})
+
+		When("A Permission references its ClusterRole with roleRef", func() {
+			var subjectPermission *v1alpha1.SubjectPermission
+
+			BeforeEach(func() {
+				subjectPermission = testconst.TestSubjectPermission.DeepCopy()
+				subjectPermission.Spec.Permissions = []v1alpha1.Permission{{
+					RoleRef:                &v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindClusterRole, Name: "testClusterRoleName"},
+					NamespacesAllowedRegex: ".*",
+				}}
+			})
+
+			It("Should not give the ClusterRole name if found", func() {
+				testClusterRoleList = &rbacv1.ClusterRoleList{
+					Items: []rbacv1.ClusterRole{{ObjectMeta: metav1.ObjectMeta{Name: "testClusterRoleName"}}},
+				}
+				Expect(PopulateCrPermissionClusterRoleNames(subjectPermission, testClusterRoleList)).To(BeEmpty())
+			})
+
+			It("Should give the ClusterRole name if not found", func() {
+				testClusterRoleList = &rbacv1.ClusterRoleList{}
+				Expect(PopulateCrPermissionClusterRoleNames(subjectPermission, testClusterRoleList)).To(Equal([]string{"testClusterRoleName"}))
+			})
+		})
 	})
 
 	Context("Running GenerateSafeList", func() {
 
 		It("Should return safe list if the deny list is blank", func() {
-			safeList := GenerateSafeList(testconst.TestDefaultAllowedList, testconst.TestEmptyDeniedList, testconst.TestNamespaceList)
+			safeList, err := GenerateSafeList(testconst.TestDefaultAllowedList, testconst.TestEmptyDeniedList, testconst.TestNamespaceList, nil)
+			Expect(err).ToNot(HaveOccurred())
 			Expect(safeList).To(ContainElement(ContainSubstring("default.whatever")))
 		})
 
 		It("Should not return any list if the deny list is same as allow list", func() {
 			TestDeniedList = "default"
-			safeList := GenerateSafeList(testconst.TestDefaultAllowedList, TestDeniedList, testconst.TestNamespaceList)
+			safeList, err := GenerateSafeList(testconst.TestDefaultAllowedList, TestDeniedList, testconst.TestNamespaceList, nil)
+			Expect(err).ToNot(HaveOccurred())
 			Expect(safeList).To(BeNil())
 		})
 
 		It("Should return safe list if allowed and is not in the deny list", func() {
 			TestDeniedList = "something"
-			safeList := GenerateSafeList(testconst.TestDefaultAllowedList, TestDeniedList, testconst.TestNamespaceList)
+			safeList, err := GenerateSafeList(testconst.TestDefaultAllowedList, TestDeniedList, testconst.TestNamespaceList, nil)
+			Expect(err).ToNot(HaveOccurred())
 			Expect(safeList).To(ContainElement(ContainSubstring("default")))
 		})
+
+		It("Should return an error instead of panicking on an invalid regex", func() {
+			Expect(func() {
+				_, err := GenerateSafeList("[invalid", "", testconst.TestNamespaceList, nil)
+				Expect(err).To(HaveOccurred())
+				_, err = GenerateSafeList(".*", "(unclosed", testconst.TestNamespaceList, nil)
+				Expect(err).To(HaveOccurred())
+			}).ToNot(Panic())
+		})
+	})
+
+	Context("Running GenerateSafeListForPermission", func() {
+		var nsList *corev1.NamespaceList
+
+		BeforeEach(func() {
+			nsList = &corev1.NamespaceList{
+				Items: []corev1.Namespace{
+					{ObjectMeta: metav1.ObjectMeta{Name: "tenant-foo", Labels: map[string]string{"tenant": "foo"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "tenant-foo-frozen", Labels: map[string]string{"tenant": "foo", "frozen": "true"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "tenant-bar", Labels: map[string]string{"tenant": "bar"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+				},
+			}
+		})
+
+		It("Matches the regexes only when no selector is set", func() {
+			safeList, err := GenerateSafeListForPermission(v1alpha1.Permission{NamespacesAllowedRegex: ".*", NamespacesDeniedRegex: "^kube-.*"}, nsList, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(safeList).To(ConsistOf("tenant-foo", "tenant-foo-frozen", "tenant-bar"))
+		})
+
+		It("Requires both the allowed regex and the namespaceSelector to match", func() {
+			permission := v1alpha1.Permission{
+				NamespacesAllowedRegex: "^tenant-foo$",
+				NamespaceSelector:      &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "foo"}},
+			}
+			safeList, err := GenerateSafeListForPermission(permission, nsList, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(safeList).To(ConsistOf("tenant-foo"))
+		})
+
+		It("Denies namespaces matching either the denied regex or the namespaceDenySelector", func() {
+			permission := v1alpha1.Permission{
+				NamespacesDeniedRegex: "^kube-.*",
+				NamespaceDenySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"frozen": "true"}},
+			}
+			safeList, err := GenerateSafeListForPermission(permission, nsList, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(safeList).To(ConsistOf("tenant-foo", "tenant-bar"))
+		})
+
+		It("Excludes the namespaces protected by the grant policy after the rules of the Permission", func() {
+			policy, err := ParseGrantPolicy(map[string]string{
+				PolicyDeniedNamespacesKey:         "^kube-.*",
+				PolicyDeniedNamespaceSelectorsKey: "frozen=true",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			safeList, err := GenerateSafeListForPermission(v1alpha1.Permission{NamespacesAllowedRegex: ".*"}, nsList, policy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(safeList).To(ConsistOf("tenant-foo", "tenant-bar"))
+
+			matcher, err := NewPermissionMatcher(v1alpha1.Permission{NamespacesAllowedRegex: "^tenant-"})
+			Expect(err).ToNot(HaveOccurred())
+			safeList, protected := matcher.SafeList(nsList, policy)
+			Expect(safeList).To(ConsistOf("tenant-foo", "tenant-bar"))
+			// only the namespaces the Permission matches are reported as protected
+			Expect(protected).To(ConsistOf("tenant-foo-frozen"))
+		})
+
+		It("Returns an error for an invalid selector", func() {
+			permission := v1alpha1.Permission{
+				NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Bogus"}}},
+			}
+			_, err := GenerateSafeListForPermission(permission, nsList, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("Running PermissionMatcher", func() {
+		var nsList *corev1.NamespaceList
+
+		BeforeEach(func() {
+			nsList = &corev1.NamespaceList{
+				Items: []corev1.Namespace{
+					{ObjectMeta: metav1.ObjectMeta{Name: "tenant-foo", Labels: map[string]string{"tenant": "foo"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "tenant-foo-frozen", Labels: map[string]string{"tenant": "foo", "frozen": "true"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "tenant-bar", Labels: map[string]string{"tenant": "bar"}}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+				},
+			}
+		})
+
+		It("Matches the same namespaces as GenerateSafeListForPermission", func() {
+			permissions := []v1alpha1.Permission{
+				{NamespacesAllowedRegex: ".*", NamespacesDeniedRegex: "^kube-.*"},
+				{NamespacesAllowedRegex: "^tenant-foo$", NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "foo"}}},
+				{NamespacesDeniedRegex: "^kube-.*", NamespaceDenySelector: &metav1.LabelSelector{MatchLabels: map[string]string{"frozen": "true"}}},
+			}
+			for _, permission := range permissions {
+				safeList, err := GenerateSafeListForPermission(permission, nsList, nil)
+				Expect(err).ToNot(HaveOccurred())
+				matcher, err := NewPermissionMatcher(permission)
+				Expect(err).ToNot(HaveOccurred())
+				var matched []string
+				for i := range nsList.Items {
+					if matcher.Matches(&nsList.Items[i]) {
+						matched = append(matched, nsList.Items[i].Name)
+					}
+				}
+				Expect(matched).To(Equal(safeList))
+			}
+		})
+
+		It("Returns an error for an invalid regex", func() {
+			_, err := NewPermissionMatcher(v1alpha1.Permission{NamespacesAllowedRegex: "[invalid"})
+			Expect(err).To(MatchError(ContainSubstring("invalid namespacesAllowedRegex")))
+			_, err = NewPermissionMatcher(v1alpha1.Permission{NamespacesDeniedRegex: "[invalid"})
+			Expect(err).To(MatchError(ContainSubstring("invalid namespacesDeniedRegex")))
+		})
+
+		It("Lists the namespaces of a regex matching a fixed list of names", func() {
+			listed := map[string][]string{
+				"^(team-a|team-b|ops)$": {"ops", "team-a", "team-b"},
+				"^team-[a-c]$":          {"team-a", "team-c"},
+				`\Ateam-a\z|^team-b$`:   {"team-a", "team-b"},
+				"^team(-staging)?$":     {"team", "team-staging"},
+				"^(team-a|team-a)$":     {"team-a"},
+			}
+			for regex, namespaces := range listed {
+				matcher, err := NewPermissionMatcher(v1alpha1.Permission{NamespacesAllowedRegex: regex, NamespacesDeniedRegex: "^team-b$"})
+				Expect(err).ToNot(HaveOccurred())
+				names, ok := matcher.ListedNamespaces()
+				Expect(ok).To(BeTrue(), regex)
+				Expect(names).To(Equal(slices.DeleteFunc(namespaces, func(name string) bool { return name == "team-b" })), regex)
+			}
+		})
+
+		It("Does not list the namespaces of an open ended regex", func() {
+			for _, regex := range []string{"", ".*", "^team-", "team-a$", "^team-a", "^team-.$", "^team-[a-z]+$", "(?i)^team-a$", "^team-a$|ops"} {
+				matcher, err := NewPermissionMatcher(v1alpha1.Permission{NamespacesAllowedRegex: regex})
+				Expect(err).ToNot(HaveOccurred())
+				_, ok := matcher.ListedNamespaces()
+				Expect(ok).To(BeFalse(), regex)
+			}
+		})
+
+		It("Compiles the matchers of a SubjectPermission again only when its generation changes", func() {
+			var cache MatcherCache
+			sp := testconst.TestSubjectPermission.DeepCopy()
+			sp.Generation = 1
+			sp.Spec.Permissions = []v1alpha1.Permission{{NamespacesAllowedRegex: "^tenant-"}, {NamespacesAllowedRegex: "[invalid"}}
+
+			matchers, errs := cache.MatchersFor(sp)
+			Expect(matchers).To(HaveLen(2))
+			Expect(errs[0]).ToNot(HaveOccurred())
+			Expect(errs[1]).To(HaveOccurred())
+			Expect(matchers[1]).To(BeNil())
+
+			cached, _ := cache.MatchersFor(sp)
+			Expect(cached[0]).To(BeIdenticalTo(matchers[0]))
+
+			sp.Generation = 2
+			sp.Spec.Permissions = []v1alpha1.Permission{{NamespacesAllowedRegex: "^kube-"}}
+			updated, errs := cache.MatchersFor(sp)
+			Expect(updated).To(HaveLen(1))
+			Expect(errs[0]).ToNot(HaveOccurred())
+			Expect(updated[0].Matches(&nsList.Items[3])).To(BeTrue())
+
+			cache.Retain(nil)
+			recompiled, _ := cache.MatchersFor(sp)
+			Expect(recompiled[0]).ToNot(BeIdenticalTo(updated[0]))
+		})
 	})
 
 	Context("Running NewRoleBindingForClusterRole", func() {
 
 		It("Should return the expected rolebinding", func() {
-			rb := NewRoleBindingForClusterRole("examplePermissionClusterRoleName", "exampleGroupName", "", "Group", "examplenamespace")
+			rb := NewRoleBindingForClusterRole(&testconst.TestSubjectPermission, "examplePermissionClusterRoleName", "exampleGroupName", "", "Group", "examplenamespace")
 			Expect(rb).To(Equal(testconst.TestRoleBinding))
 		})
 
 		It("Should return the expected rolebinding for SA", func() {
-			rb := NewRoleBindingForClusterRole("examplePermissionClusterRoleName", "exampleGroupName", "exampleGroupNamespace", "ServiceAccount", "examplenamespace")
+			rb := NewRoleBindingForClusterRole(&testconst.TestSubjectPermission, "examplePermissionClusterRoleName", "exampleGroupName", "exampleGroupNamespace", "ServiceAccount", "examplenamespace")
 			Expect(rb).To(Equal(testconst.TestRoleBindingSA))
 		})
 	})
 
-	Context("Running UpdateCondition", func() {
+	Context("Running NewRoleBinding", func() {
 
-		It("Updates the conditions as expected by adding the clusterrole with no existing condition", func() {
-			conditions := UpdateCondition(testconst.TestConditions, "testMessage", []string{"testClusterRoleName"}, false, testconst.TestSubjectPermissionState, testconst.TestSubjectPermissionType)
-			Expect(conditions).To(HaveLen(3))
-			Expect(conditions[2].ClusterRoleNames[0]).To(Equal("testClusterRoleName"))
-			Expect(conditions[2].Type).To(Equal(testconst.TestSubjectPermissionType))
+		It("Binds a Role with a binding name apart from the ClusterRole of the same name", func() {
+			role := v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindRole, Name: "deployer"}
+			rb := NewRoleBinding(&testconst.TestSubjectPermission, role, "devs", "", "Group", "examplenamespace")
+			Expect(rb.RoleRef).To(Equal(rbacv1.RoleRef{Kind: "Role", Name: "deployer"}))
+			Expect(rb.Name).To(Equal(RoleBindingName(&testconst.TestSubjectPermission, role, "Group", "", "devs")))
+			Expect(rb.Name).ToNot(Equal(BindingName(&testconst.TestSubjectPermission, "deployer", "Group", "", "devs")))
 		})
 
-		It("Updates the conditions as expected by adding the clusterrole with existing condition", func() {
-			TestConditions = []v1alpha1.Condition{
-				{
-					ClusterRoleNames: []string{"exampleClusterRoleName"},
-					Message:          "exampleMessage",
-					Status:           true,
-					State:            "exampleState",
-				},
-				{
-					ClusterRoleNames: []string{"testClusterRoleName"},
-					Type:             testconst.TestSubjectPermissionType,
-				},
+		It("Binds a ClusterRole like NewRoleBindingForClusterRole", func() {
+			role := v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindClusterRole, Name: "examplePermissionClusterRoleName"}
+			Expect(NewRoleBinding(&testconst.TestSubjectPermission, role, "exampleGroupName", "", "Group", "examplenamespace")).To(Equal(testconst.TestRoleBinding))
+		})
+	})
+
+	Context("Running SetOwnershipMetadata", func() {
+
+		It("Labels and annotates the binding with the owning SubjectPermission", func() {
+			sp := testconst.TestSubjectPermission
+			sp.UID = "1234"
+			rb := NewRoleBindingForClusterRole(&testconst.TestSubjectPermission, "examplePermissionClusterRoleName", "exampleGroupName", "", "Group", "examplenamespace")
+			SetOwnershipMetadata(rb, &sp, "examplePermissionClusterRoleName")
+			Expect(rb.Labels).To(HaveKeyWithValue(ManagedByLabel, "rbac-permissions-operator"))
+			Expect(rb.Labels).To(HaveKeyWithValue(SubjectPermissionNamespaceLabel, sp.Namespace))
+			Expect(rb.Labels).To(HaveKeyWithValue(SubjectPermissionNameLabel, sp.Name))
+			Expect(rb.Labels).To(HaveKeyWithValue(SubjectPermissionUIDLabel, "1234"))
+			Expect(rb.Labels).To(HaveKeyWithValue(PermissionHashLabel, PermissionHash("examplePermissionClusterRoleName")))
+			Expect(rb.Annotations).To(HaveKeyWithValue(SubjectPermissionAnnotation, sp.Namespace+"/"+sp.Name))
+			Expect(IsOwnedBy(rb, &sp)).To(BeTrue())
+			Expect(IsManagedByOperator(rb)).To(BeTrue())
+		})
+
+		It("Does not consider a binding of another SubjectPermission as owned", func() {
+			sp := testconst.TestSubjectPermission
+			other := testconst.TestSubjectPermission
+			other.Name = "otherSubjectPermission"
+			rb := NewRoleBindingForClusterRole(&testconst.TestSubjectPermission, "examplePermissionClusterRoleName", "exampleGroupName", "", "Group", "examplenamespace")
+			SetOwnershipMetadata(rb, &other, "examplePermissionClusterRoleName")
+			Expect(IsOwnedBy(rb, &sp)).To(BeFalse())
+		})
+	})
+
+	Context("Running LabelValue", func() {
+
+		It("Keeps values that fit in a label", func() {
+			Expect(LabelValue("dedicated-admins")).To(Equal("dedicated-admins"))
+		})
+
+		It("Truncates long values deterministically", func() {
+			long := strings.Repeat("a", 100)
+			Expect(LabelValue(long)).To(HaveLen(63))
+			Expect(LabelValue(long)).To(Equal(LabelValue(long)))
+			Expect(LabelValue(long)).ToNot(Equal(LabelValue(long + "b")))
+		})
+	})
+
+	Context("Running BindingName", func() {
+
+		It("Includes the subject kind and a hash", func() {
+			Expect(BindingName(&testconst.TestSubjectPermission, "view", "Group", "", "devs")).To(MatchRegexp(`^view-group-devs-[0-9a-f]{10}$`))
+		})
+
+		It("Does not collide for subjects of different kinds", func() {
+			Expect(BindingName(&testconst.TestSubjectPermission, "view", "Group", "", "devs")).ToNot(Equal(BindingName(&testconst.TestSubjectPermission, "view", "User", "", "devs")))
+		})
+
+		It("Does not collide for ServiceAccounts in different namespaces", func() {
+			Expect(BindingName(&testconst.TestSubjectPermission, "view", "ServiceAccount", "ns-a", "builder")).ToNot(Equal(BindingName(&testconst.TestSubjectPermission, "view", "ServiceAccount", "ns-b", "builder")))
+		})
+
+		It("Does not collide for SubjectPermissions granting the same role to the same subject", func() {
+			other := testconst.TestSubjectPermission.DeepCopy()
+			other.Name = "other"
+			Expect(BindingName(other, "view", "Group", "", "devs")).ToNot(Equal(BindingName(&testconst.TestSubjectPermission, "view", "Group", "", "devs")))
+		})
+
+		It("Ignores the namespace of subjects that are not ServiceAccounts", func() {
+			Expect(BindingName(&testconst.TestSubjectPermission, "view", "Group", "ns-a", "devs")).To(Equal(BindingName(&testconst.TestSubjectPermission, "view", "Group", "", "devs")))
+		})
+
+		It("Truncates long names deterministically", func() {
+			long := strings.Repeat("a", 300)
+			Expect(BindingName(&testconst.TestSubjectPermission, long, "Group", "", "devs")).To(HaveLen(253))
+			Expect(BindingName(&testconst.TestSubjectPermission, long, "Group", "", "devs")).ToNot(Equal(BindingName(&testconst.TestSubjectPermission, long, "Group", "", "ops")))
+		})
+	})
+
+	Context("Running OwnerOf", func() {
+
+		It("Returns the SubjectPermission recorded on a managed binding", func() {
+			sp := testconst.TestSubjectPermission
+			rb := NewRoleBindingForClusterRole(&testconst.TestSubjectPermission, "examplePermissionClusterRoleName", "exampleGroupName", "", "Group", "examplenamespace")
+			SetOwnershipMetadata(rb, &sp, "examplePermissionClusterRoleName")
+			owner, ok := OwnerOf(rb)
+			Expect(ok).To(BeTrue())
+			Expect(owner).To(Equal(types.NamespacedName{Namespace: sp.Namespace, Name: sp.Name}))
+		})
+
+		It("Returns the ClusterSubjectPermission recorded on a managed binding without a namespace", func() {
+			csp := &v1alpha1.ClusterSubjectPermission{ObjectMeta: metav1.ObjectMeta{Name: "dedicated-admins"}}
+			rb := NewRoleBindingForClusterRole(&testconst.TestSubjectPermission, "examplePermissionClusterRoleName", "exampleGroupName", "", "Group", "examplenamespace")
+			SetOwnershipMetadata(rb, csp, "examplePermissionClusterRoleName")
+			owner, ok := OwnerOf(rb)
+			Expect(ok).To(BeTrue())
+			Expect(owner).To(Equal(types.NamespacedName{Name: "dedicated-admins"}))
+			Expect(IsOwnedBy(rb, csp)).To(BeTrue())
+			Expect(IsOwnedBy(rb, &testconst.TestSubjectPermission)).To(BeFalse())
+		})
+
+		It("Ignores bindings not managed by the operator", func() {
+			_, ok := OwnerOf(testconst.TestRoleBinding)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("Running IndexByOwner", func() {
+
+		It("Indexes a managed binding on its SubjectPermission", func() {
+			sp := testconst.TestSubjectPermission
+			rb := NewRoleBindingForClusterRole(&testconst.TestSubjectPermission, "examplePermissionClusterRoleName", "exampleGroupName", "", "Group", "examplenamespace")
+			SetOwnershipMetadata(rb, &sp, "examplePermissionClusterRoleName")
+			Expect(IndexByOwner(rb)).To(Equal([]string{OwnerIndexValue(&sp)}))
+			Expect(IndexByOwner(testconst.TestRoleBinding)).To(BeEmpty())
+		})
+	})
+
+	Context("Running EnsureRoleBinding", func() {
+		var (
+			mockClient *clientmocks.MockClient
+			sp         v1alpha1.SubjectPermission
+			desired    *rbacv1.RoleBinding
+			existing   *rbacv1.RoleBinding
+		)
+
+		BeforeEach(func() {
+			mockClient = clientmocks.NewMockClient(mockCtrl)
+			sp = testconst.TestSubjectPermission
+			desired = NewRoleBindingForClusterRole(&testconst.TestSubjectPermission, "examplePermissionClusterRoleName", "exampleGroupName", "", "Group", "examplenamespace")
+			SetOwnershipMetadata(desired, &sp, "examplePermissionClusterRoleName")
+			existing = desired.DeepCopy()
+		})
+
+		It("Creates a missing RoleBinding", func() {
+			mockClient.EXPECT().Create(gomock.Any(), desired).Times(1).Return(nil)
+			op, err := EnsureRoleBinding(context.TODO(), mockClient, desired, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(op).To(Equal(ctrlutil.OperationResultCreated))
+		})
+
+		It("Leaves a RoleBinding in the desired state alone", func() {
+			op, err := EnsureRoleBinding(context.TODO(), mockClient, desired, existing)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(op).To(Equal(ctrlutil.OperationResultNone))
+		})
+
+		It("Patches the subjects of an edited RoleBinding", func() {
+			existing.Subjects = append(existing.Subjects, rbacv1.Subject{Kind: "User", Name: "intruder"})
+			mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+				func(ctx context.Context, rb *rbacv1.RoleBinding, patch client.Patch, po ...client.PatchOption) error {
+					Expect(rb.Subjects).To(Equal(desired.Subjects))
+					return nil
+				})
+			op, err := EnsureRoleBinding(context.TODO(), mockClient, desired, existing)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(op).To(Equal(ctrlutil.OperationResultUpdated))
+		})
+
+		It("Restores removed ownership labels", func() {
+			existing.Labels = nil
+			mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+				func(ctx context.Context, rb *rbacv1.RoleBinding, patch client.Patch, po ...client.PatchOption) error {
+					Expect(IsOwnedBy(rb, &sp)).To(BeTrue())
+					return nil
+				})
+			op, err := EnsureRoleBinding(context.TODO(), mockClient, desired, existing)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(op).To(Equal(ctrlutil.OperationResultUpdated))
+		})
+
+		It("Recreates a RoleBinding whose roleRef changed", func() {
+			existing.RoleRef.Name = "cluster-admin"
+			gomock.InOrder(
+				mockClient.EXPECT().Delete(gomock.Any(), existing).Times(1).Return(nil),
+				mockClient.EXPECT().Create(gomock.Any(), desired).Times(1).Return(nil),
+			)
+			op, err := EnsureRoleBinding(context.TODO(), mockClient, desired, existing)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(op).To(Equal(ctrlutil.OperationResultUpdated))
+		})
+	})
+
+	Context("Running EnsureClusterRole", func() {
+		var (
+			mockClient *clientmocks.MockClient
+			sp         v1alpha1.SubjectPermission
+			definition v1alpha1.ManagedClusterRole
+			desired    *rbacv1.ClusterRole
+			existing   *rbacv1.ClusterRole
+		)
+
+		BeforeEach(func() {
+			mockClient = clientmocks.NewMockClient(mockCtrl)
+			sp = testconst.TestSubjectPermission
+			definition = v1alpha1.ManagedClusterRole{
+				Name:  "tenant-deployer",
+				Rules: []rbacv1.PolicyRule{{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "update"}}},
 			}
-			conditions := UpdateCondition(TestConditions, "testMessage", []string{"testClusterRoleName"}, false, testconst.TestSubjectPermissionState, testconst.TestSubjectPermissionType)
-			Expect(conditions).To(HaveLen(2))
-			Expect(conditions[1].ClusterRoleNames[0]).To(Equal("testClusterRoleName"))
-			Expect(conditions[1].State).To(Equal(testconst.TestSubjectPermissionState))
-			Expect(conditions[1].Status).To(Equal(false))
+			desired = NewManagedClusterRole(&sp, definition)
+			existing = desired.DeepCopy()
 		})
 
-		It("Updates the conditions as expected by adding the clusterrole with existing condition but with different status", func() {
-			TestConditions = []v1alpha1.Condition{
-				{
-					ClusterRoleNames: []string{"exampleClusterRoleName"},
-					Message:          "exampleMessage",
-					Status:           true,
-					State:            "exampleState",
-				},
-				{
-					ClusterRoleNames: []string{"testClusterRoleName"},
-					Type:             testconst.TestSubjectPermissionType,
+		It("Labels the ClusterRole as owned by the SubjectPermission", func() {
+			Expect(desired.Name).To(Equal("tenant-deployer"))
+			Expect(desired.Rules).To(Equal(definition.Rules))
+			Expect(IsOwnedBy(desired, &sp)).To(BeTrue())
+		})
+
+		It("Creates a missing ClusterRole", func() {
+			mockClient.EXPECT().Create(gomock.Any(), desired).Times(1).Return(nil)
+			op, err := EnsureClusterRole(context.TODO(), mockClient, desired, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(op).To(Equal(ctrlutil.OperationResultCreated))
+		})
+
+		It("Patches the rules of an edited ClusterRole", func() {
+			existing.Rules[0].Verbs = []string{"*"}
+			mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+				func(ctx context.Context, cr *rbacv1.ClusterRole, patch client.Patch, po ...client.PatchOption) error {
+					Expect(cr.Rules).To(Equal(definition.Rules))
+					return nil
+				})
+			Expect(PlanClusterRole(desired, existing)).To(Equal(ctrlutil.OperationResultUpdated))
+			op, err := EnsureClusterRole(context.TODO(), mockClient, desired, existing)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(op).To(Equal(ctrlutil.OperationResultUpdated))
+		})
+
+		It("Leaves the rules of an aggregated ClusterRole to the cluster", func() {
+			definition.Rules = nil
+			definition.AggregationRule = &rbacv1.AggregationRule{ClusterRoleSelectors: []metav1.LabelSelector{{MatchLabels: map[string]string{"tenant": "foo"}}}}
+			desired = NewManagedClusterRole(&sp, definition)
+			existing = desired.DeepCopy()
+			existing.Rules = []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}}
+			op, err := EnsureClusterRole(context.TODO(), mockClient, desired, existing)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(op).To(Equal(ctrlutil.OperationResultNone))
+		})
+	})
+
+	Context("Running SubjectsOf", func() {
+
+		It("Returns the legacy subject followed by the subjects list without duplicates", func() {
+			spec := v1alpha1.SubjectPermissionSpec{
+				SubjectKind: "Group",
+				SubjectName: "devs",
+				Subjects: []rbacv1.Subject{
+					{Kind: "Group", Name: "devs"},
+					{Kind: "ServiceAccount", Name: "builder", Namespace: "ns-a"},
+					{Kind: "ServiceAccount", Name: "builder", Namespace: "ns-b"},
 				},
 			}
-			conditions := UpdateCondition(TestConditions, "testMessage", []string{"testClusterRoleName"}, true, testconst.TestSubjectPermissionState, testconst.TestSubjectPermissionType)
-			Expect(conditions).To(HaveLen(2))
-			Expect(conditions[1].ClusterRoleNames[0]).To(Equal("testClusterRoleName"))
-			Expect(conditions[1].State).To(Equal(testconst.TestSubjectPermissionState))
-			Expect(conditions[1].Status).To(Equal(true))
+			subjects := SubjectsOf(&spec)
+			Expect(subjects).To(HaveLen(3))
+			Expect(subjects[0].Name).To(Equal("devs"))
+			Expect(subjects[1].Namespace).To(Equal("ns-a"))
+			Expect(subjects[2].Namespace).To(Equal("ns-b"))
+		})
+
+		It("Returns only the subjects list when the legacy subject is not set", func() {
+			spec := v1alpha1.SubjectPermissionSpec{
+				Subjects: []rbacv1.Subject{{Kind: "User", Name: "alice"}},
+			}
+			Expect(SubjectsOf(&spec)).To(Equal([]rbacv1.Subject{{Kind: "User", Name: "alice"}}))
+		})
+	})
+
+	Context("Running NewSubject", func() {
+
+		It("Sets the rbac API group for users and groups", func() {
+			Expect(NewSubject("Group", "devs", "ignored")).To(Equal(rbacv1.Subject{Kind: "Group", APIGroup: rbacv1.GroupName, Name: "devs"}))
+			Expect(NewSubject("User", "alice", "")).To(Equal(rbacv1.Subject{Kind: "User", APIGroup: rbacv1.GroupName, Name: "alice"}))
+		})
+
+		It("Sets the namespace for service accounts", func() {
+			Expect(NewSubject("ServiceAccount", "builder", "ns-a")).To(Equal(rbacv1.Subject{Kind: "ServiceAccount", Name: "builder", Namespace: "ns-a"}))
+		})
+	})
+
+	Context("Running the plan helpers", func() {
+
+		It("Plans the change EnsureRoleBinding would make", func() {
+			desired := NewRoleBindingForClusterRole(&testconst.TestSubjectPermission, "examplePermissionClusterRoleName", "exampleGroupName", "", "Group", "examplenamespace")
+			Expect(PlanRoleBinding(desired, nil)).To(Equal(ctrlutil.OperationResultCreated))
+			Expect(PlanRoleBinding(desired, desired.DeepCopy())).To(Equal(ctrlutil.OperationResultNone))
+			edited := desired.DeepCopy()
+			edited.Subjects = append(edited.Subjects, rbacv1.Subject{Kind: "User", Name: "intruder"})
+			Expect(PlanRoleBinding(desired, edited)).To(Equal(ctrlutil.OperationResultUpdated))
+		})
+
+		It("Counts every planned binding and lists the first ones sorted", func() {
+			changes := v1alpha1.BindingChanges{}
+			for i := v1alpha1.MaxPlannedBindings + 5; i > 0; i-- {
+				RecordPlannedChange(&changes, ctrlutil.OperationResultCreated, fmt.Sprintf("binding-%03d", i))
+			}
+			RecordPlannedChange(&changes, ctrlutil.OperationResultNone, "unchanged")
+			RecordPlannedDeletion(&changes, "stale")
+			TruncatePlannedChanges(&changes)
+			Expect(changes.Create).To(Equal(v1alpha1.MaxPlannedBindings + 5))
+			Expect(changes.ToCreate).To(HaveLen(v1alpha1.MaxPlannedBindings))
+			Expect(changes.ToCreate[0]).To(Equal("binding-001"))
+			Expect(changes.Update).To(BeZero())
+			Expect(changes.ToDelete).To(Equal([]string{"stale"}))
+		})
+	})
+
+	Context("Running the permission inventory helpers", func() {
+		It("Adds the status of a ClusterRole only once", func() {
+			var statuses []v1alpha1.PermissionStatus
+			admin := v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindClusterRole, Name: "admin"}
+			PermissionStatusFor(&statuses, admin).RoleBindings++
+			PermissionStatusFor(&statuses, v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindClusterRole, Name: "view"})
+			PermissionStatusFor(&statuses, admin).RoleBindings++
+			Expect(statuses).To(Equal([]v1alpha1.PermissionStatus{{ClusterRoleName: "admin", RoleBindings: 2}, {ClusterRoleName: "view"}}))
+			Expect(FindPermissionStatus(statuses, v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindClusterRole, Name: "edit"})).To(BeNil())
+		})
+
+		It("Keeps the status of a Role apart from the ClusterRole of the same name", func() {
+			var statuses []v1alpha1.PermissionStatus
+			PermissionStatusFor(&statuses, v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindClusterRole, Name: "admin"})
+			PermissionStatusFor(&statuses, v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindRole, Name: "admin"}).RoleBindings++
+			Expect(statuses).To(Equal([]v1alpha1.PermissionStatus{{ClusterRoleName: "admin"}, {ClusterRoleName: "admin", Kind: v1alpha1.RoleKindRole, RoleBindings: 1}}))
+		})
+
+		It("Keeps the namespaces missing a Role sorted, unique and bounded", func() {
+			status := &v1alpha1.PermissionStatus{ClusterRoleName: "deployer", Kind: v1alpha1.RoleKindRole}
+			for i := v1alpha1.MaxMissingRoleNamespaces + 5; i > 0; i-- {
+				AddMissingRoleNamespace(status, fmt.Sprintf("ns-%02d", i))
+			}
+			AddMissingRoleNamespace(status, "ns-01")
+			Expect(status.MissingRoleNamespaces).To(HaveLen(v1alpha1.MaxMissingRoleNamespaces))
+			Expect(status.MissingRoleNamespaces[0]).To(Equal("ns-01"))
+			RemoveMissingRoleNamespace(status, "ns-01")
+			Expect(status.MissingRoleNamespaces[0]).To(Equal("ns-02"))
+		})
+
+		It("Keeps the failing namespaces sorted, unique and bounded", func() {
+			status := &v1alpha1.PermissionStatus{ClusterRoleName: "admin"}
+			for i := v1alpha1.MaxFailedNamespaces + 5; i > 0; i-- {
+				RecordNamespaceFailure(status, fmt.Sprintf("ns-%02d", i), "fake error")
+			}
+			RecordNamespaceFailure(status, "ns-01", "other error")
+			Expect(status.FailedNamespaces).To(HaveLen(v1alpha1.MaxFailedNamespaces))
+			Expect(status.FailedNamespaces[0]).To(Equal(v1alpha1.NamespaceFailure{Namespace: "ns-01", Reason: "other error"}))
+			Expect(status.FailedNamespaces[1].Namespace).To(Equal("ns-02"))
+		})
+
+		It("Clears the failure of a namespace", func() {
+			status := &v1alpha1.PermissionStatus{ClusterRoleName: "admin"}
+			RecordNamespaceFailure(status, "ns-a", "fake error")
+			RecordNamespaceFailure(status, "ns-b", "fake error")
+			ClearNamespaceFailure(status, "ns-a")
+			Expect(status.FailedNamespaces).To(ConsistOf(v1alpha1.NamespaceFailure{Namespace: "ns-b", Reason: "fake error"}))
+			ClearNamespaceFailure(status, "ns-b")
+			Expect(status.FailedNamespaces).To(BeNil())
+		})
+	})
+
+	Context("Running UpdateSubjectStatuses", func() {
+
+		It("Keeps counts that were not observed and drops removed subjects", func() {
+			statuses := []v1alpha1.SubjectStatus{
+				{Kind: "Group", Name: "devs", ClusterRoleBindings: 1, RoleBindings: 4},
+				{Kind: "Group", Name: "removed", ClusterRoleBindings: 1, RoleBindings: 1},
+			}
+			subjects := []rbacv1.Subject{{Kind: "Group", Name: "devs"}, {Kind: "User", Name: "alice"}}
+			result := UpdateSubjectStatuses(statuses, subjects, map[string]int{"Group/devs": 2, "User/alice": 2}, nil)
+			Expect(result).To(Equal([]v1alpha1.SubjectStatus{
+				{Kind: "Group", Name: "devs", ClusterRoleBindings: 2, RoleBindings: 4},
+				{Kind: "User", Name: "alice", ClusterRoleBindings: 2},
+			}))
+		})
+	})
+
+	Context("Running UpdateCondition", func() {
+		var sp *v1alpha1.SubjectPermission
+
+		BeforeEach(func() {
+			sp = testconst.TestSubjectPermission.DeepCopy()
+			sp.Generation = 3
+			sp.Status.Conditions = append([]metav1.Condition{}, testconst.TestConditions...)
+		})
+
+		It("Adds a condition that does not exist yet", func() {
+			changed := UpdateCondition(sp, v1alpha1.ConditionProgressing, metav1.ConditionTrue, "testReason", "testMessage")
+			Expect(changed).To(BeTrue())
+			Expect(sp.Status.Conditions).To(HaveLen(3))
+			condition := meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionProgressing)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Reason).To(Equal("testReason"))
+			Expect(condition.ObservedGeneration).To(Equal(int64(3)))
+			Expect(condition.LastTransitionTime.IsZero()).To(BeFalse())
+			Expect(sp.Status.ObservedGeneration).To(Equal(int64(3)))
+		})
+
+		It("Keeps the transition time when the status of an existing condition does not change", func() {
+			transitionTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+			sp.Status.Conditions[1].LastTransitionTime = transitionTime
+			UpdateCondition(sp, v1alpha1.ConditionDegraded, metav1.ConditionFalse, "testReason", "newMessage")
+			Expect(sp.Status.Conditions).To(HaveLen(2))
+			Expect(sp.Status.Conditions[1].Message).To(Equal("newMessage"))
+			Expect(sp.Status.Conditions[1].LastTransitionTime).To(Equal(transitionTime))
+		})
+
+		It("Moves the transition time when the status of an existing condition changes", func() {
+			transitionTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+			sp.Status.Conditions[1].LastTransitionTime = transitionTime
+			UpdateCondition(sp, v1alpha1.ConditionDegraded, metav1.ConditionTrue, "testReason", "testMessage")
+			Expect(sp.Status.Conditions[1].Status).To(Equal(metav1.ConditionTrue))
+			Expect(sp.Status.Conditions[1].LastTransitionTime).ToNot(Equal(transitionTime))
+		})
+
+		It("Removes conditions written by earlier versions of the operator", func() {
+			sp.Status.Conditions = append(sp.Status.Conditions, metav1.Condition{Type: v1alpha1.LegacyClusterRoleBindingCreated, Status: metav1.ConditionTrue, Reason: "Created"})
+			MarkReady(sp, "testMessage")
+			Expect(meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.LegacyClusterRoleBindingCreated)).To(BeNil())
+			Expect(meta.IsStatusConditionTrue(sp.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(sp.Status.Conditions, v1alpha1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(sp.Status.Conditions, v1alpha1.ConditionProgressing)).To(BeTrue())
+		})
+
+		It("Marks a degraded SubjectPermission as not Ready", func() {
+			MarkDegraded(sp, v1alpha1.ReasonClusterRoleNotFound, "testMessage")
+			Expect(meta.IsStatusConditionFalse(sp.Status.Conditions, v1alpha1.ConditionReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(sp.Status.Conditions, v1alpha1.ConditionDegraded)).To(BeTrue())
+			Expect(meta.FindStatusCondition(sp.Status.Conditions, v1alpha1.ConditionReady).Reason).To(Equal(v1alpha1.ReasonClusterRoleNotFound))
+		})
+	})
+
+	Context("Running the grant policy helpers", func() {
+
+		It("Parses the ClusterRoles and namespaces of the operator ConfigMap", func() {
+			policy, err := ParseGrantPolicy(map[string]string{
+				PolicyClusterScopeAllowedKey:   "view\nedit, admin",
+				PolicyNamespaceScopeDeniedKey:  "# never granted\ncluster-admin",
+				PolicyDeniedNamespacesKey:      "^openshift-.*\n^kube-.*",
+				PolicyNamespaceScopeAllowedKey: "",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policy.ClusterScope.Allowed).To(Equal([]string{"view", "edit", "admin"}))
+			Expect(policy.PermitsClusterRole("edit")).To(BeTrue())
+			Expect(policy.PermitsClusterRole("cluster-admin")).To(BeFalse())
+			Expect(policy.PermitsNamespacedClusterRole("admin")).To(BeTrue())
+			Expect(policy.PermitsNamespacedClusterRole("cluster-admin")).To(BeFalse())
+			Expect(policy.PermitsNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}})).To(BeFalse())
+			Expect(policy.PermitsNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}})).To(BeTrue())
+		})
+
+		It("Only grants the ClusterRoles a SubjectPermission defines when the policy explicitly allows them", func() {
+			policy, err := ParseGrantPolicy(map[string]string{
+				PolicyClusterScopeDeniedKey:    "cluster-admin",
+				PolicyNamespaceScopeAllowedKey: "tenant-deployer",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			spec := &v1alpha1.SubjectPermissionSpec{ClusterRoles: []v1alpha1.ManagedClusterRole{{Name: "tenant-admin"}, {Name: "tenant-deployer"}}}
+			Expect(policy.PermitsClusterRoleOf(spec, "view")).To(BeTrue())
+			Expect(policy.PermitsClusterRoleOf(spec, "tenant-admin")).To(BeFalse())
+			Expect(policy.PermitsNamespacedClusterRoleOf(spec, "tenant-admin")).To(BeFalse())
+			Expect(policy.PermitsNamespacedClusterRoleOf(spec, "tenant-deployer")).To(BeTrue())
+
+			var unset *GrantPolicy
+			Expect(unset.PermitsClusterRoleOf(spec, "tenant-admin")).To(BeTrue())
+			Expect(RoleRules{}.PermitsDefined("tenant-admin")).To(BeTrue())
+		})
+
+		It("Gives denied ClusterRoles precedence over allowed ones", func() {
+			rules := RoleRules{Allowed: []string{"admin"}, Denied: []string{"admin"}}
+			Expect(rules.Permits("admin")).To(BeFalse())
+		})
+
+		It("Rejects an invalid denied namespace pattern", func() {
+			_, err := ParseGrantPolicy(map[string]string{PolicyDeniedNamespacesKey: "(openshift-"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Protects the namespaces matching a denied selector", func() {
+			policy, err := ParseGrantPolicy(map[string]string{
+				PolicyDeniedNamespaceSelectorsKey: "# infrastructure\nopenshift.io/run-level in (0,1)\ntier=infra,!tenant",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policy.DeniedNamespaceSelectors).To(HaveLen(2))
+			Expect(policy.PermitsNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{"openshift.io/run-level": "0"}}})).To(BeFalse())
+			Expect(policy.PermitsNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "b", Labels: map[string]string{"tier": "infra"}}})).To(BeFalse())
+			Expect(policy.PermitsNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "c", Labels: map[string]string{"tier": "infra", "tenant": "foo"}}})).To(BeTrue())
+		})
+
+		It("Rejects an invalid or empty denied namespace selector", func() {
+			_, err := ParseGrantPolicy(map[string]string{PolicyDeniedNamespaceSelectorsKey: "tier in infra"})
+			Expect(err).To(HaveOccurred())
+			_, err = ParseGrantPolicy(map[string]string{PolicyDeniedNamespaceSelectorsKey: ","})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Keeps the protected namespaces of a Permission sorted and bounded", func() {
+			status := &v1alpha1.PermissionStatus{ClusterRoleName: "admin"}
+			for i := v1alpha1.MaxProtectedNamespaces + 5; i > 0; i-- {
+				AddProtectedNamespace(status, fmt.Sprintf("ns-%03d", i))
+			}
+			AddProtectedNamespace(status, "ns-001")
+			Expect(status.ProtectedNamespaces).To(HaveLen(v1alpha1.MaxProtectedNamespaces))
+			Expect(status.ProtectedNamespaces[0]).To(Equal("ns-001"))
+			Expect(slices.IsSorted(status.ProtectedNamespaces)).To(BeTrue())
+			RemoveProtectedNamespace(status, "ns-001")
+			Expect(status.ProtectedNamespaces[0]).To(Equal("ns-002"))
+		})
+
+		It("Permits everything without a policy", func() {
+			var policy *GrantPolicy
+			Expect(policy.PermitsClusterRole("cluster-admin")).To(BeTrue())
+			Expect(policy.PermitsNamespacedClusterRole("cluster-admin")).To(BeTrue())
+			Expect(policy.PermitsNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}})).To(BeTrue())
+		})
+	})
+
+	Context("Indexing SubjectPermissions by ClusterRole", func() {
+		It("Returns every ClusterRole granted by the SubjectPermission once", func() {
+			subjectPermission := testconst.TestSubjectPermission.DeepCopy()
+			subjectPermission.Spec.ClusterPermissions = []string{"view", "exampleClusterRoleName"}
+			Expect(IndexByClusterRole(subjectPermission)).To(Equal([]string{"exampleClusterRoleName", "testClusterRoleName", "view"}))
+		})
+
+		It("Indexes a ClusterSubjectPermission the same way", func() {
+			csp := &v1alpha1.ClusterSubjectPermission{Spec: v1alpha1.SubjectPermissionSpec{ClusterPermissions: []string{"view"}}}
+			Expect(IndexByClusterRole(csp)).To(Equal([]string{"view"}))
+		})
+
+		It("Does not index other objects", func() {
+			Expect(IndexByClusterRole(&rbacv1.ClusterRole{})).To(BeEmpty())
 		})
 
+		It("Indexes the Roles of Permissions apart from the ClusterRoles", func() {
+			subjectPermission := testconst.TestSubjectPermission.DeepCopy()
+			subjectPermission.Spec.Permissions = append(subjectPermission.Spec.Permissions,
+				v1alpha1.Permission{RoleRef: &v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindRole, Name: "deployer"}},
+				v1alpha1.Permission{RoleRef: &v1alpha1.PermissionRoleRef{Kind: v1alpha1.RoleKindRole, Name: "deployer"}})
+			Expect(IndexByRole(subjectPermission)).To(Equal([]string{"deployer"}))
+			Expect(IndexByClusterRole(subjectPermission)).ToNot(ContainElement("deployer"))
+		})
+	})
+
+	Context("Recording Events", func() {
+		It("Emits the Event on the SubjectPermission and on its Namespace", func() {
+			recorder := events.NewFakeRecorder(2)
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+			RecordEvent(recorder, &testconst.TestSubjectPermission, namespace, corev1.EventTypeNormal, EventReasonBindingCreated, EventActionGrant, "Created RoleBinding %s", "admin")
+			Expect(recorder.Events).To(HaveLen(2))
+			Expect(<-recorder.Events).To(Equal("Normal BindingCreated Created RoleBinding admin"))
+			Expect(<-recorder.Events).To(Equal("Normal BindingCreated Created RoleBinding admin"))
+		})
+
+		It("Emits the Event on the SubjectPermission only without a Namespace", func() {
+			recorder := events.NewFakeRecorder(2)
+			RecordEvent(recorder, &testconst.TestSubjectPermission, nil, corev1.EventTypeWarning, EventReasonClusterRoleMissing, EventActionGrant, "ClusterRole %s does not exist", "admin")
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(<-recorder.Events).To(Equal("Warning ClusterRoleMissing ClusterRole admin does not exist"))
+		})
+
+		It("Emits nothing without a recorder", func() {
+			Expect(func() {
+				RecordEvent(nil, &testconst.TestSubjectPermission, nil, corev1.EventTypeNormal, EventReasonBindingRemoved, EventActionRevoke, "Deleted")
+			}).ToNot(Panic())
+		})
+	})
+
+	Context("Running the expiration helpers", func() {
+		It("Expires at expiresAt or once the duration elapsed since the creation", func() {
+			now := time.Now()
+			sp := testconst.TestSubjectPermission.DeepCopy()
+			Expect(ExpirationOf(sp)).To(BeNil())
+			Expect(IsExpired(sp, now)).To(BeFalse())
+
+			sp.CreationTimestamp = metav1.NewTime(now.Add(-2 * time.Hour))
+			sp.Spec.Duration = &metav1.Duration{Duration: time.Hour}
+			Expect(ExpirationOf(sp).Time).To(BeTemporally("==", now.Add(-time.Hour)))
+			Expect(IsExpired(sp, now)).To(BeTrue())
+
+			expiresAt := metav1.NewTime(now.Add(time.Hour))
+			sp.Spec.Duration = nil
+			sp.Spec.ExpiresAt = &expiresAt
+			Expect(IsExpired(sp, now)).To(BeFalse())
+			Expect(IsExpired(sp, expiresAt.Time)).To(BeTrue())
+		})
 	})
 
 })
@@ -0,0 +1,73 @@
+package util
+
+import (
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/events"
+)
+
+// Reasons of the Events emitted on SubjectPermissions and Namespaces
+const (
+	// EventReasonBindingCreated is emitted when a ClusterRoleBinding or RoleBinding is created
+	EventReasonBindingCreated = "BindingCreated"
+	// EventReasonBindingRestored is emitted when a binding edited outside of the operator is restored
+	EventReasonBindingRestored = "BindingRestored"
+	// EventReasonBindingRemoved is emitted when a binding that is no longer granted is deleted
+	EventReasonBindingRemoved = "BindingRemoved"
+	// EventReasonBindingFailed is emitted when a binding cannot be applied or deleted
+	EventReasonBindingFailed = "BindingFailed"
+	// EventReasonClusterRoleMissing is emitted when a referenced ClusterRole does not exist
+	EventReasonClusterRoleMissing = "ClusterRoleMissing"
+	// EventReasonRoleMissing is emitted when an allowed namespace does not hold the Role of a Permission
+	EventReasonRoleMissing = "RoleMissing"
+	// EventReasonClusterRoleCreated is emitted when a ClusterRole defined by a SubjectPermission is created
+	EventReasonClusterRoleCreated = "ClusterRoleCreated"
+	// EventReasonClusterRoleRestored is emitted when a ClusterRole defined by a SubjectPermission and edited outside
+	// of the operator is restored
+	EventReasonClusterRoleRestored = "ClusterRoleRestored"
+	// EventReasonClusterRoleRemoved is emitted when a ClusterRole no longer defined by its SubjectPermission is deleted
+	EventReasonClusterRoleRemoved = "ClusterRoleRemoved"
+	// EventReasonClusterRoleFailed is emitted when a ClusterRole defined by a SubjectPermission cannot be applied or deleted
+	EventReasonClusterRoleFailed = "ClusterRoleFailed"
+	// EventReasonClusterRoleConflict is emitted when a ClusterRole defined by a SubjectPermission already exists
+	// and was not created for it
+	EventReasonClusterRoleConflict = managedv1alpha1.ReasonClusterRoleConflict
+	// EventReasonExpired is emitted when the bindings of an expired SubjectPermission are revoked
+	EventReasonExpired = managedv1alpha1.ReasonExpired
+	// EventReasonValidationFailed is emitted when the spec of a SubjectPermission is invalid
+	EventReasonValidationFailed = "ValidationFailed"
+	// EventReasonNamespaceExcluded is emitted when the grant policy protects a namespace matched by a Permission
+	EventReasonNamespaceExcluded = "NamespaceExcluded"
+	// EventReasonPolicyViolation is emitted when the grant policy denies a ClusterRole
+	EventReasonPolicyViolation = managedv1alpha1.ReasonPolicyViolation
+)
+
+// Actions of the Events, what the operator did or failed to do
+const (
+	EventActionGrant    = "Grant"
+	EventActionRevoke   = "Revoke"
+	EventActionValidate = "Validate"
+)
+
+// RecordEvent emits an Event on the SubjectPermission and, when the namespace is set, the same Event on the
+// Namespace, so it shows up when describing either of them. A nil recorder emits nothing
+func RecordEvent(recorder events.EventRecorder, sp managedv1alpha1.SubjectPermissionObject, namespace *corev1.Namespace, eventtype, reason, action, note string, args ...interface{}) {
+	if recorder == nil {
+		return
+	}
+	if namespace == nil {
+		recorder.Eventf(sp, nil, eventtype, reason, action, note, args...)
+		return
+	}
+	recorder.Eventf(sp, namespace, eventtype, reason, action, note, args...)
+	recorder.Eventf(namespace, sp, eventtype, reason, action, note, args...)
+}
+
+// NamespaceIndex returns the Namespaces of the list by name, to find the Namespace of a RoleBinding
+func NamespaceIndex(nsList *corev1.NamespaceList) map[string]*corev1.Namespace {
+	index := make(map[string]*corev1.Namespace, len(nsList.Items))
+	for i := range nsList.Items {
+		index[nsList.Items[i].Name] = &nsList.Items[i]
+	}
+	return index
+}
@@ -0,0 +1,29 @@
+package util
+
+import (
+	"time"
+
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExpirationOf returns the time the bindings of the SubjectPermission are revoked at, from its expiresAt or from its
+// duration counted from its creation, or nil when it does not expire. Both are stored on the object, so the
+// expiration survives restarts of the operator
+func ExpirationOf(sp managedv1alpha1.SubjectPermissionObject) *metav1.Time {
+	spec := sp.GetSpec()
+	switch {
+	case spec.ExpiresAt != nil:
+		return spec.ExpiresAt.DeepCopy()
+	case spec.Duration != nil:
+		expiresAt := metav1.NewTime(sp.GetCreationTimestamp().Add(spec.Duration.Duration))
+		return &expiresAt
+	}
+	return nil
+}
+
+// IsExpired checks if the expiration of the SubjectPermission passed at the given time
+func IsExpired(sp managedv1alpha1.SubjectPermissionObject, now time.Time) bool {
+	expiresAt := ExpirationOf(sp)
+	return expiresAt != nil && !now.Before(expiresAt.Time)
+}
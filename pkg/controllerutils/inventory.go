@@ -0,0 +1,101 @@
+package util
+
+import (
+	"slices"
+	"strings"
+
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+)
+
+// FindPermissionStatus returns the status reported for the ClusterRole or the Role of a Permission, or nil
+func FindPermissionStatus(statuses []managedv1alpha1.PermissionStatus, role managedv1alpha1.PermissionRoleRef) *managedv1alpha1.PermissionStatus {
+	for i := range statuses {
+		if statuses[i].Role() == role {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// PermissionStatusFor returns the status reported for the ClusterRole or the Role of a Permission, adding it to
+// the statuses when it is not reported yet. The returned pointer is valid until the next call
+func PermissionStatusFor(statuses *[]managedv1alpha1.PermissionStatus, role managedv1alpha1.PermissionRoleRef) *managedv1alpha1.PermissionStatus {
+	if status := FindPermissionStatus(*statuses, role); status != nil {
+		return status
+	}
+	status := managedv1alpha1.PermissionStatus{ClusterRoleName: role.Name}
+	if role.IsRole() {
+		status.Kind = managedv1alpha1.RoleKindRole
+	}
+	*statuses = append(*statuses, status)
+	return &(*statuses)[len(*statuses)-1]
+}
+
+// RecordNamespaceFailure reports the namespace as failing for the Permission, replacing an earlier failure
+// for the same namespace. Failures are sorted by namespace and capped at MaxFailedNamespaces entries
+func RecordNamespaceFailure(status *managedv1alpha1.PermissionStatus, namespace, reason string) {
+	ClearNamespaceFailure(status, namespace)
+	status.FailedNamespaces = append(status.FailedNamespaces, managedv1alpha1.NamespaceFailure{Namespace: namespace, Reason: reason})
+	slices.SortFunc(status.FailedNamespaces, func(a, b managedv1alpha1.NamespaceFailure) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+	if len(status.FailedNamespaces) > managedv1alpha1.MaxFailedNamespaces {
+		status.FailedNamespaces = status.FailedNamespaces[:managedv1alpha1.MaxFailedNamespaces]
+	}
+}
+
+// ClearNamespaceFailure removes the namespace from the failing namespaces of the Permission
+func ClearNamespaceFailure(status *managedv1alpha1.PermissionStatus, namespace string) {
+	status.FailedNamespaces = slices.DeleteFunc(status.FailedNamespaces, func(failure managedv1alpha1.NamespaceFailure) bool {
+		return failure.Namespace == namespace
+	})
+	if len(status.FailedNamespaces) == 0 {
+		status.FailedNamespaces = nil
+	}
+}
+
+// AddProtectedNamespace reports the namespace as protected from the Permission by the grant policy.
+// Protected namespaces are sorted and capped at MaxProtectedNamespaces entries
+func AddProtectedNamespace(status *managedv1alpha1.PermissionStatus, namespace string) {
+	i, found := slices.BinarySearch(status.ProtectedNamespaces, namespace)
+	if found {
+		return
+	}
+	status.ProtectedNamespaces = slices.Insert(status.ProtectedNamespaces, i, namespace)
+	if len(status.ProtectedNamespaces) > managedv1alpha1.MaxProtectedNamespaces {
+		status.ProtectedNamespaces = status.ProtectedNamespaces[:managedv1alpha1.MaxProtectedNamespaces]
+	}
+}
+
+// RemoveProtectedNamespace removes the namespace from the protected namespaces of the Permission
+func RemoveProtectedNamespace(status *managedv1alpha1.PermissionStatus, namespace string) {
+	status.ProtectedNamespaces = slices.DeleteFunc(status.ProtectedNamespaces, func(protected string) bool {
+		return protected == namespace
+	})
+	if len(status.ProtectedNamespaces) == 0 {
+		status.ProtectedNamespaces = nil
+	}
+}
+
+// AddMissingRoleNamespace reports the namespace as not holding the Role of the Permission.
+// The namespaces are sorted and capped at MaxMissingRoleNamespaces entries
+func AddMissingRoleNamespace(status *managedv1alpha1.PermissionStatus, namespace string) {
+	i, found := slices.BinarySearch(status.MissingRoleNamespaces, namespace)
+	if found {
+		return
+	}
+	status.MissingRoleNamespaces = slices.Insert(status.MissingRoleNamespaces, i, namespace)
+	if len(status.MissingRoleNamespaces) > managedv1alpha1.MaxMissingRoleNamespaces {
+		status.MissingRoleNamespaces = status.MissingRoleNamespaces[:managedv1alpha1.MaxMissingRoleNamespaces]
+	}
+}
+
+// RemoveMissingRoleNamespace removes the namespace from the namespaces missing the Role of the Permission
+func RemoveMissingRoleNamespace(status *managedv1alpha1.PermissionStatus, namespace string) {
+	status.MissingRoleNamespaces = slices.DeleteFunc(status.MissingRoleNamespaces, func(missing string) bool {
+		return missing == namespace
+	})
+	if len(status.MissingRoleNamespaces) == 0 {
+		status.MissingRoleNamespaces = nil
+	}
+}
@@ -0,0 +1,169 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	"github.com/openshift/rbac-permissions-operator/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ManagedByLabel is set on every binding created by the operator
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// SubjectPermissionNamespaceLabel holds the namespace of the owning SubjectPermission, it is empty for a ClusterSubjectPermission
+	SubjectPermissionNamespaceLabel = "managed.openshift.io/subjectpermission-namespace"
+	// SubjectPermissionNameLabel holds the name of the owning SubjectPermission
+	SubjectPermissionNameLabel = "managed.openshift.io/subjectpermission-name"
+	// SubjectPermissionUIDLabel holds the UID of the owning SubjectPermission
+	SubjectPermissionUIDLabel = "managed.openshift.io/subjectpermission-uid"
+	// PermissionHashLabel identifies the permission of the SubjectPermission the binding was created for
+	PermissionHashLabel = "managed.openshift.io/permission-hash"
+	// SubjectPermissionAnnotation holds the untruncated "<namespace>/<name>" of the owning SubjectPermission,
+	// or "/<name>" for a ClusterSubjectPermission
+	SubjectPermissionAnnotation = "managed.openshift.io/subjectpermission"
+	// SubjectPermissionOwnerField is the field index of bindings on the "<namespace>/<name>" of the owning SubjectPermission
+	SubjectPermissionOwnerField = "subjectPermissionOwner"
+	// ClusterRoleReferenceField is the field index of SubjectPermissions on the names of the ClusterRoles they grant
+	ClusterRoleReferenceField = "clusterRoleReference"
+	// RoleReferenceField is the field index of SubjectPermissions on the names of the Roles their Permissions grant
+	RoleReferenceField = "roleReference"
+
+	// maxLabelValueLength is the maximum length of a label value
+	maxLabelValueLength = 63
+	// hashLength is the number of hex characters kept from a sha256 sum
+	hashLength = 10
+)
+
+// SetOwnershipMetadata labels and annotates a binding with the SubjectPermission and permission it was created for.
+// The permission is identified by its ClusterRole, or by the PermissionRoleRef.String of its Role
+func SetOwnershipMetadata(obj metav1.Object, subjectPermission managedv1alpha1.SubjectPermissionObject, clusterRoleName string) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for key, value := range OwnerLabels(subjectPermission) {
+		labels[key] = value
+	}
+	labels[SubjectPermissionUIDLabel] = string(subjectPermission.GetUID())
+	labels[PermissionHashLabel] = PermissionHash(clusterRoleName)
+	obj.SetLabels(labels)
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[SubjectPermissionAnnotation] = subjectPermission.GetNamespace() + "/" + subjectPermission.GetName()
+	obj.SetAnnotations(annotations)
+}
+
+// OwnerLabels returns the labels that select every binding created for the SubjectPermission
+func OwnerLabels(subjectPermission managedv1alpha1.SubjectPermissionObject) map[string]string {
+	return map[string]string{
+		ManagedByLabel:                  config.OperatorName,
+		SubjectPermissionNamespaceLabel: LabelValue(subjectPermission.GetNamespace()),
+		SubjectPermissionNameLabel:      LabelValue(subjectPermission.GetName()),
+	}
+}
+
+// IsOwnedBy checks if a binding carries the ownership labels of the SubjectPermission
+func IsOwnedBy(obj metav1.Object, subjectPermission managedv1alpha1.SubjectPermissionObject) bool {
+	labels := obj.GetLabels()
+	for key, value := range OwnerLabels(subjectPermission) {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// OwnerOf returns the SubjectPermission a binding was created for, as recorded in its annotation.
+// The namespace is empty for a ClusterSubjectPermission
+func OwnerOf(obj metav1.Object) (types.NamespacedName, bool) {
+	if !IsManagedByOperator(obj) {
+		return types.NamespacedName{}, false
+	}
+	namespace, name, found := strings.Cut(obj.GetAnnotations()[SubjectPermissionAnnotation], "/")
+	if !found || name == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, true
+}
+
+// OwnerIndexValue returns the SubjectPermissionOwnerField value of the bindings created for the SubjectPermission
+func OwnerIndexValue(subjectPermission managedv1alpha1.SubjectPermissionObject) string {
+	return types.NamespacedName{Namespace: subjectPermission.GetNamespace(), Name: subjectPermission.GetName()}.String()
+}
+
+// IndexByOwner indexes a binding created by the operator on SubjectPermissionOwnerField
+func IndexByOwner(obj client.Object) []string {
+	owner, ok := OwnerOf(obj)
+	if !ok {
+		return nil
+	}
+	return []string{owner.String()}
+}
+
+// IndexByClusterRole indexes a SubjectPermission on ClusterRoleReferenceField with every ClusterRole of its
+// clusterPermissions and permissions
+func IndexByClusterRole(obj client.Object) []string {
+	subjectPermission, ok := obj.(managedv1alpha1.SubjectPermissionObject)
+	if !ok {
+		return nil
+	}
+	spec := subjectPermission.GetSpec()
+	clusterRoleNames := slices.Clone(spec.ClusterPermissions)
+	for _, permission := range spec.Permissions {
+		if role := permission.Role(); !role.IsRole() {
+			clusterRoleNames = append(clusterRoleNames, role.Name)
+		}
+	}
+	slices.Sort(clusterRoleNames)
+	return slices.Compact(clusterRoleNames)
+}
+
+// IndexByRole indexes a SubjectPermission on RoleReferenceField with the Role of each of its permissions
+func IndexByRole(obj client.Object) []string {
+	subjectPermission, ok := obj.(managedv1alpha1.SubjectPermissionObject)
+	if !ok {
+		return nil
+	}
+	var roleNames []string
+	for _, permission := range subjectPermission.GetSpec().Permissions {
+		if role := permission.Role(); role.IsRole() {
+			roleNames = append(roleNames, role.Name)
+		}
+	}
+	slices.Sort(roleNames)
+	return slices.Compact(roleNames)
+}
+
+// IsManagedByOperator checks if a binding carries the managed-by label of the operator
+func IsManagedByOperator(obj metav1.Object) bool {
+	return obj.GetLabels()[ManagedByLabel] == config.OperatorName
+}
+
+// PermissionHash returns a short, label safe hash identifying the permission granting clusterRoleName
+func PermissionHash(clusterRoleName string) string {
+	return shortHash(clusterRoleName)
+}
+
+// LabelValue returns value if it fits in a label, otherwise a truncated value with a hash suffix
+func LabelValue(value string) string {
+	if len(value) <= maxLabelValueLength {
+		return value
+	}
+	prefix := value[:maxLabelValueLength-hashLength-1]
+	return prefix + "-" + shortHash(value)
+}
+
+// shortHash returns the first hashLength hex characters of the sha256 sum of value
+func shortHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])[:hashLength]
+}
@@ -0,0 +1,253 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"sync"
+
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// PermissionMatcher decides whether a Namespace is part of a Permission.
+// The regular expressions and label selectors of the Permission are compiled once, so a
+// Namespace is evaluated without going through the other Namespaces of the cluster
+type PermissionMatcher struct {
+	allowedRegex  *regexp.Regexp
+	deniedRegex   *regexp.Regexp
+	allowSelector labels.Selector
+	denySelector  labels.Selector
+}
+
+// NewPermissionMatcher compiles the regular expressions and label selectors of the Permission
+func NewPermissionMatcher(permission managedv1alpha1.Permission) (*PermissionMatcher, error) {
+	allowedRegex, err := regexp.Compile(permission.NamespacesAllowedRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespacesAllowedRegex: %w", err)
+	}
+	matcher := &PermissionMatcher{
+		allowedRegex:  allowedRegex,
+		allowSelector: labels.Everything(),
+		denySelector:  labels.Nothing(),
+	}
+	if permission.NamespacesDeniedRegex != "" {
+		matcher.deniedRegex, err = regexp.Compile(permission.NamespacesDeniedRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespacesDeniedRegex: %w", err)
+		}
+	}
+	if permission.NamespaceSelector != nil {
+		matcher.allowSelector, err = metav1.LabelSelectorAsSelector(permission.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespaceSelector: %w", err)
+		}
+	}
+	if permission.NamespaceDenySelector != nil {
+		matcher.denySelector, err = metav1.LabelSelectorAsSelector(permission.NamespaceDenySelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespaceDenySelector: %w", err)
+		}
+	}
+	return matcher, nil
+}
+
+// Matches checks if the Permission applies to the Namespace
+func (m *PermissionMatcher) Matches(namespace *corev1.Namespace) bool {
+	nsLabels := labels.Set(namespace.Labels)
+	if !m.allowSelector.Matches(nsLabels) || m.denySelector.Matches(nsLabels) {
+		return false
+	}
+	if !m.allowedRegex.MatchString(namespace.Name) {
+		return false
+	}
+	return m.deniedRegex == nil || !m.deniedRegex.MatchString(namespace.Name)
+}
+
+// SafeList returns the names of the Namespaces of the list the Permission applies to. The namespaces protected by
+// the grant policy are excluded after the rules of the Permission, and returned as protected
+func (m *PermissionMatcher) SafeList(nsList *corev1.NamespaceList, policy *GrantPolicy) (safeList, protected []string) {
+	for i := range nsList.Items {
+		if !m.Matches(&nsList.Items[i]) {
+			continue
+		}
+		if !policy.PermitsNamespace(&nsList.Items[i]) {
+			protected = append(protected, nsList.Items[i].Name)
+			continue
+		}
+		safeList = append(safeList, nsList.Items[i].Name)
+	}
+	return safeList, protected
+}
+
+// maxListedNamespaces bounds the names a namespacesAllowedRegex may list before it is considered open ended
+const maxListedNamespaces = 100
+
+// ListedNamespaces returns the names of every Namespace the Permission can apply to when its namespacesAllowedRegex
+// only matches a fixed list of names, such as ^(team-a|team-b)$, including the Namespaces that do not exist yet.
+// The names matching namespacesDeniedRegex are left out, the label selectors are not applied.
+// It returns false when the regular expression matches an open ended set of names
+func (m *PermissionMatcher) ListedNamespaces() ([]string, bool) {
+	re, err := syntax.Parse(m.allowedRegex.String(), syntax.Perl)
+	if err != nil {
+		return nil, false
+	}
+	// the anchors are expanded as markers, a listed name must start and end with them
+	expanded, ok := expandRegexp(re.Simplify())
+	if !ok {
+		return nil, false
+	}
+	var names []string
+	for _, candidate := range expanded {
+		runes := []rune(candidate)
+		if len(runes) < 2 || runes[0] != beginTextMarker || runes[len(runes)-1] != endTextMarker {
+			return nil, false
+		}
+		name := string(runes[1 : len(runes)-1])
+		if strings.ContainsRune(name, beginTextMarker) || strings.ContainsRune(name, endTextMarker) {
+			// an anchor inside the name never matches
+			continue
+		}
+		if m.deniedRegex != nil && m.deniedRegex.MatchString(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names), true
+}
+
+// the markers standing for the ^ and $ anchors in the names expanded from a regular expression
+const (
+	beginTextMarker = '\x00'
+	endTextMarker   = '\x01'
+)
+
+// expandRegexp returns every string the regular expression matches, with the anchors as markers.
+// It returns false when they are not a fixed list of up to maxListedNamespaces strings
+func expandRegexp(re *syntax.Regexp) ([]string, bool) {
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		return []string{""}, true
+	case syntax.OpBeginText:
+		return []string{string(beginTextMarker)}, true
+	case syntax.OpEndText:
+		return []string{string(endTextMarker)}, true
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return nil, false
+		}
+		return []string{string(re.Rune)}, true
+	case syntax.OpCharClass:
+		var expanded []string
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				if len(expanded) == maxListedNamespaces {
+					return nil, false
+				}
+				expanded = append(expanded, string(r))
+			}
+		}
+		return expanded, true
+	case syntax.OpCapture:
+		return expandRegexp(re.Sub[0])
+	case syntax.OpAlternate:
+		var expanded []string
+		for _, sub := range re.Sub {
+			alternative, ok := expandRegexp(sub)
+			if !ok || len(expanded)+len(alternative) > maxListedNamespaces {
+				return nil, false
+			}
+			expanded = append(expanded, alternative...)
+		}
+		return expanded, true
+	case syntax.OpConcat:
+		expanded := []string{""}
+		for _, sub := range re.Sub {
+			suffixes, ok := expandRegexp(sub)
+			if !ok || len(expanded)*len(suffixes) > maxListedNamespaces {
+				return nil, false
+			}
+			var concatenated []string
+			for _, prefix := range expanded {
+				for _, suffix := range suffixes {
+					concatenated = append(concatenated, prefix+suffix)
+				}
+			}
+			expanded = concatenated
+		}
+		return expanded, true
+	case syntax.OpQuest:
+		expanded, ok := expandRegexp(re.Sub[0])
+		if !ok || len(expanded) == maxListedNamespaces {
+			return nil, false
+		}
+		return append(expanded, ""), true
+	}
+	// repetitions and the other operators match an open ended set of strings
+	return nil, false
+}
+
+// MatcherCache keeps the PermissionMatchers of every SubjectPermission and ClusterSubjectPermission until its spec changes.
+// The zero value is ready to use and safe for concurrent reconciles
+type MatcherCache struct {
+	mu      sync.Mutex
+	entries map[types.NamespacedName]*matcherCacheEntry
+}
+
+// matcherCacheEntry holds the PermissionMatchers compiled for a generation of a SubjectPermission
+type matcherCacheEntry struct {
+	uid        types.UID
+	generation int64
+	matchers   []*PermissionMatcher
+	errs       []error
+}
+
+// MatchersFor returns a PermissionMatcher for every Permission of the SubjectPermission, in the same order.
+// The matcher of a Permission that cannot be compiled is nil and its error is set at the same index
+func (c *MatcherCache) MatchersFor(subjectPermission managedv1alpha1.SubjectPermissionObject) ([]*PermissionMatcher, []error) {
+	key := types.NamespacedName{Namespace: subjectPermission.GetNamespace(), Name: subjectPermission.GetName()}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok && entry.uid == subjectPermission.GetUID() && entry.generation == subjectPermission.GetGeneration() {
+		return entry.matchers, entry.errs
+	}
+
+	entry := &matcherCacheEntry{
+		uid:        subjectPermission.GetUID(),
+		generation: subjectPermission.GetGeneration(),
+		matchers:   make([]*PermissionMatcher, len(subjectPermission.GetSpec().Permissions)),
+		errs:       make([]error, len(subjectPermission.GetSpec().Permissions)),
+	}
+	for i, permission := range subjectPermission.GetSpec().Permissions {
+		entry.matchers[i], entry.errs[i] = NewPermissionMatcher(permission)
+	}
+	if c.entries == nil {
+		c.entries = map[types.NamespacedName]*matcherCacheEntry{}
+	}
+	c.entries[key] = entry
+	return entry.matchers, entry.errs
+}
+
+// Retain drops the matchers of the SubjectPermissions and ClusterSubjectPermissions that are not part of the list,
+// as they were deleted
+func (c *MatcherCache) Retain(subjectPermissions []managedv1alpha1.SubjectPermissionObject) {
+	live := make(map[types.NamespacedName]bool, len(subjectPermissions))
+	for _, subjectPermission := range subjectPermissions {
+		live[types.NamespacedName{Namespace: subjectPermission.GetNamespace(), Name: subjectPermission.GetName()}] = true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if !live[key] {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"strings"
+
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	v1 "k8s.io/api/rbac/v1"
+)
+
+// maxBindingNameLength is the maximum length of a generated binding name
+const maxBindingNameLength = 253
+
+// BindingName returns the deterministic name of a binding the SubjectPermission owner creates to grant clusterRoleName
+// to a subject. The name is built as "<clusterRoleName>-<kind>-[<subjectNamespace>-]<subjectName>-<hash>" where
+// the hash covers all parts and the owner, so subjects of different kinds or namespaces never collide even when
+// the readable prefix has to be truncated to fit the name length limit, and two SubjectPermissions granting the same
+// role to the same subject each own their binding.
+// The subject namespace is only part of the name for ServiceAccounts, the only namespaced subject kind.
+func BindingName(owner managedv1alpha1.SubjectPermissionObject, clusterRoleName, subjectKind, subjectNamespace, subjectName string) string {
+	return bindingName(owner, clusterRoleName, clusterRoleName, subjectKind, subjectNamespace, subjectName)
+}
+
+// RoleBindingName returns the deterministic name of a RoleBinding granting the role of a Permission to a subject.
+// The RoleBindings of a ClusterRole are named by BindingName, those of a Role start with "role-<roleName>" and hash
+// the kind of the role too, so they never collide with the RoleBindings of a ClusterRole of the same name
+func RoleBindingName(owner managedv1alpha1.SubjectPermissionObject, role managedv1alpha1.PermissionRoleRef, subjectKind, subjectNamespace, subjectName string) string {
+	if !role.IsRole() {
+		return BindingName(owner, role.Name, subjectKind, subjectNamespace, subjectName)
+	}
+	return bindingName(owner, "role-"+role.Name, role.String(), subjectKind, subjectNamespace, subjectName)
+}
+
+// bindingName builds a binding name from its readable prefix, the subject and a hash of the owner, the role and the subject
+func bindingName(owner managedv1alpha1.SubjectPermissionObject, prefix, role, subjectKind, subjectNamespace, subjectName string) string {
+	if subjectKind != v1.ServiceAccountKind {
+		subjectNamespace = ""
+	}
+	parts := []string{prefix, strings.ToLower(subjectKind)}
+	if subjectNamespace != "" {
+		parts = append(parts, subjectNamespace)
+	}
+	parts = append(parts, subjectName)
+
+	hash := shortHash(strings.Join([]string{OwnerIndexValue(owner), role, subjectKind, subjectNamespace, subjectName}, "/"))
+	name := strings.Join(parts, "-")
+	if maxPrefix := maxBindingNameLength - len(hash) - 1; len(name) > maxPrefix {
+		name = name[:maxPrefix]
+	}
+	return name + "-" + hash
+}
+
+// LegacyBindingName returns the name bindings were created with by earlier versions of the operator
+func LegacyBindingName(clusterRoleName, subjectName string) string {
+	return clusterRoleName + "-" + subjectName
+}
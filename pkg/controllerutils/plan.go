@@ -0,0 +1,38 @@
+package util
+
+import (
+	"slices"
+
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// RecordPlannedChange adds the binding to the planned changes for the result of PlanClusterRoleBinding
+// or PlanRoleBinding. Bindings that are already in their desired state are not a change
+func RecordPlannedChange(changes *managedv1alpha1.BindingChanges, op ctrlutil.OperationResult, name string) {
+	switch op {
+	case ctrlutil.OperationResultCreated:
+		changes.Create++
+		changes.ToCreate = append(changes.ToCreate, name)
+	case ctrlutil.OperationResultUpdated:
+		changes.Update++
+		changes.ToUpdate = append(changes.ToUpdate, name)
+	}
+}
+
+// RecordPlannedDeletion adds the binding to the planned deletions
+func RecordPlannedDeletion(changes *managedv1alpha1.BindingChanges, name string) {
+	changes.Delete++
+	changes.ToDelete = append(changes.ToDelete, name)
+}
+
+// TruncatePlannedChanges sorts the planned bindings and keeps the first MaxPlannedBindings of each change,
+// so the plan only changes when the bindings do. The counts keep covering every binding
+func TruncatePlannedChanges(changes *managedv1alpha1.BindingChanges) {
+	for _, names := range []*[]string{&changes.ToCreate, &changes.ToUpdate, &changes.ToDelete} {
+		slices.Sort(*names)
+		if len(*names) > managedv1alpha1.MaxPlannedBindings {
+			*names = (*names)[:managedv1alpha1.MaxPlannedBindings]
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+)
+
+// Keys of the operator ConfigMap holding the grant policy. Each value lists one entry per line or comma separated,
+// lines starting with # are ignored
+const (
+	// PolicyClusterScopeAllowedKey lists the only ClusterRoles that may be granted with a ClusterRoleBinding
+	PolicyClusterScopeAllowedKey = "clusterScopeAllowedClusterRoles"
+	// PolicyClusterScopeDeniedKey lists the ClusterRoles that may never be granted with a ClusterRoleBinding
+	PolicyClusterScopeDeniedKey = "clusterScopeDeniedClusterRoles"
+	// PolicyNamespaceScopeAllowedKey lists the only ClusterRoles that may be granted with a RoleBinding
+	PolicyNamespaceScopeAllowedKey = "namespaceScopeAllowedClusterRoles"
+	// PolicyNamespaceScopeDeniedKey lists the ClusterRoles that may never be granted with a RoleBinding
+	PolicyNamespaceScopeDeniedKey = "namespaceScopeDeniedClusterRoles"
+	// PolicyDeniedNamespacesKey lists the regular expressions of the protected namespaces, which never receive a RoleBinding
+	PolicyDeniedNamespacesKey = "deniedNamespaces"
+	// PolicyDeniedNamespaceSelectorsKey lists the label selectors of the protected namespaces, one per line
+	// as the selectors themselves are comma separated
+	PolicyDeniedNamespaceSelectorsKey = "deniedNamespaceSelectors"
+)
+
+// RoleRules decides which ClusterRoles may be granted in a scope.
+// An empty Allowed list allows every ClusterRole, Denied takes precedence over Allowed
+type RoleRules struct {
+	Allowed []string
+	Denied  []string
+}
+
+// Permits checks if the ClusterRole may be granted
+func (r RoleRules) Permits(clusterRoleName string) bool {
+	if slices.Contains(r.Denied, clusterRoleName) {
+		return false
+	}
+	return len(r.Allowed) == 0 || slices.Contains(r.Allowed, clusterRoleName)
+}
+
+// PermitsDefined checks if a ClusterRole defined by a SubjectPermission may be granted. Its rules are written by the
+// author of the SubjectPermission and could copy the rules of a denied ClusterRole, so once the scope is limited it
+// must be explicitly allowed
+func (r RoleRules) PermitsDefined(clusterRoleName string) bool {
+	if len(r.Allowed) == 0 && len(r.Denied) == 0 {
+		return true
+	}
+	return !slices.Contains(r.Denied, clusterRoleName) && slices.Contains(r.Allowed, clusterRoleName)
+}
+
+// GrantPolicy is the operator level policy limiting what SubjectPermissions can grant.
+// A nil GrantPolicy grants everything, as the operator did before the policy existed
+type GrantPolicy struct {
+	// ClusterScope limits the ClusterRoles of ClusterPermissions
+	ClusterScope RoleRules
+	// NamespaceScope limits the ClusterRoles of Permissions
+	NamespaceScope RoleRules
+	// DeniedNamespaces match the names of the protected namespaces, which never receive a RoleBinding
+	DeniedNamespaces []*regexp.Regexp
+	// DeniedNamespaceSelectors match the labels of the protected namespaces
+	DeniedNamespaceSelectors []labels.Selector
+}
+
+// PermitsClusterRole checks if the ClusterRole may be granted with a ClusterRoleBinding
+func (p *GrantPolicy) PermitsClusterRole(clusterRoleName string) bool {
+	return p == nil || p.ClusterScope.Permits(clusterRoleName)
+}
+
+// PermitsNamespacedClusterRole checks if the ClusterRole may be granted with a RoleBinding
+func (p *GrantPolicy) PermitsNamespacedClusterRole(clusterRoleName string) bool {
+	return p == nil || p.NamespaceScope.Permits(clusterRoleName)
+}
+
+// PermitsClusterRoleOf checks if the SubjectPermission may grant the ClusterRole with a ClusterRoleBinding,
+// the ClusterRoles defined in its clusterRoles have to be explicitly allowed by a policy limiting the cluster scope
+func (p *GrantPolicy) PermitsClusterRoleOf(spec *managedv1alpha1.SubjectPermissionSpec, clusterRoleName string) bool {
+	if p == nil {
+		return true
+	}
+	if definesClusterRole(spec, clusterRoleName) {
+		return p.ClusterScope.PermitsDefined(clusterRoleName)
+	}
+	return p.ClusterScope.Permits(clusterRoleName)
+}
+
+// PermitsNamespacedClusterRoleOf checks if the SubjectPermission may grant the ClusterRole with a RoleBinding,
+// the ClusterRoles defined in its clusterRoles have to be explicitly allowed by a policy limiting the namespace scope
+func (p *GrantPolicy) PermitsNamespacedClusterRoleOf(spec *managedv1alpha1.SubjectPermissionSpec, clusterRoleName string) bool {
+	if p == nil {
+		return true
+	}
+	if definesClusterRole(spec, clusterRoleName) {
+		return p.NamespaceScope.PermitsDefined(clusterRoleName)
+	}
+	return p.NamespaceScope.Permits(clusterRoleName)
+}
+
+// definesClusterRole checks if the ClusterRole is defined in the clusterRoles of the SubjectPermission
+func definesClusterRole(spec *managedv1alpha1.SubjectPermissionSpec, clusterRoleName string) bool {
+	return slices.ContainsFunc(spec.ClusterRoles, func(definition managedv1alpha1.ManagedClusterRole) bool {
+		return definition.Name == clusterRoleName
+	})
+}
+
+// PermitsNamespace checks if RoleBindings may be created in the namespace, that is if it is not protected
+// by its name or its labels
+func (p *GrantPolicy) PermitsNamespace(namespace *corev1.Namespace) bool {
+	if p == nil {
+		return true
+	}
+	for _, deniedRegex := range p.DeniedNamespaces {
+		if deniedRegex.MatchString(namespace.Name) {
+			return false
+		}
+	}
+	nsLabels := labels.Set(namespace.Labels)
+	for _, deniedSelector := range p.DeniedNamespaceSelectors {
+		if deniedSelector.Matches(nsLabels) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseGrantPolicy reads the grant policy from the data of the operator ConfigMap.
+// An error is returned when a denied namespace pattern or selector cannot be parsed
+func ParseGrantPolicy(data map[string]string) (*GrantPolicy, error) {
+	policy := &GrantPolicy{
+		ClusterScope: RoleRules{
+			Allowed: policyEntries(data[PolicyClusterScopeAllowedKey]),
+			Denied:  policyEntries(data[PolicyClusterScopeDeniedKey]),
+		},
+		NamespaceScope: RoleRules{
+			Allowed: policyEntries(data[PolicyNamespaceScopeAllowedKey]),
+			Denied:  policyEntries(data[PolicyNamespaceScopeDeniedKey]),
+		},
+	}
+	for _, pattern := range policyEntries(data[PolicyDeniedNamespacesKey]) {
+		deniedRegex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", PolicyDeniedNamespacesKey, pattern, err)
+		}
+		policy.DeniedNamespaces = append(policy.DeniedNamespaces, deniedRegex)
+	}
+	for _, line := range policyLines(data[PolicyDeniedNamespaceSelectorsKey]) {
+		deniedSelector, err := labels.Parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s selector %q: %w", PolicyDeniedNamespaceSelectorsKey, line, err)
+		}
+		// an empty selector would protect every namespace, which is never what was meant
+		if deniedSelector.Empty() {
+			return nil, fmt.Errorf("invalid %s selector %q: must not be empty", PolicyDeniedNamespaceSelectorsKey, line)
+		}
+		policy.DeniedNamespaceSelectors = append(policy.DeniedNamespaceSelectors, deniedSelector)
+	}
+	return policy, nil
+}
+
+// policyEntries splits a ConfigMap value into its entries, one per line or comma separated
+func policyEntries(value string) []string {
+	var entries []string
+	for _, line := range policyLines(value) {
+		for _, entry := range strings.Split(line, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+// policyLines splits a ConfigMap value into its non empty lines, without the comments
+func policyLines(value string) []string {
+	var lines []string
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
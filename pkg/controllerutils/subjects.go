@@ -0,0 +1,84 @@
+package util
+
+import (
+	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
+	v1 "k8s.io/api/rbac/v1"
+)
+
+// SubjectsOf returns every Subject of the SubjectPermission: the Subject set with the legacy
+// SubjectKind, SubjectName and SubjectNamespace fields first, followed by the Subjects list, without duplicates
+func SubjectsOf(spec *managedv1alpha1.SubjectPermissionSpec) []v1.Subject {
+	var candidates []v1.Subject
+	if spec.SubjectName != "" {
+		candidates = append(candidates, v1.Subject{
+			Kind:      spec.SubjectKind,
+			Name:      spec.SubjectName,
+			Namespace: spec.SubjectNamespace,
+		})
+	}
+	candidates = append(candidates, spec.Subjects...)
+
+	var subjects []v1.Subject
+	seen := map[string]bool{}
+	for _, subject := range candidates {
+		key := SubjectKey(subject)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		subjects = append(subjects, subject)
+	}
+	return subjects
+}
+
+// NewSubject returns the Subject of a binding. User and Group subjects belong to the rbac.authorization.k8s.io
+// API group, ServiceAccounts to the core group and are the only subjects carrying a namespace
+func NewSubject(subjectKind, subjectName, subjectNamespace string) v1.Subject {
+	if subjectKind == v1.ServiceAccountKind {
+		return v1.Subject{
+			Kind:      subjectKind,
+			Name:      subjectName,
+			Namespace: subjectNamespace,
+		}
+	}
+	return v1.Subject{
+		Kind:     subjectKind,
+		APIGroup: v1.GroupName,
+		Name:     subjectName,
+	}
+}
+
+// SubjectKey identifies a Subject, the namespace is only significant for ServiceAccounts
+func SubjectKey(subject v1.Subject) string {
+	if subject.Kind != v1.ServiceAccountKind {
+		return subject.Kind + "/" + subject.Name
+	}
+	return subject.Kind + "/" + subject.Namespace + "/" + subject.Name
+}
+
+// UpdateSubjectStatuses returns the status of every Subject with the binding counts observed by the caller.
+// A nil map of counts keeps the count previously reported for the Subject, Subjects that are no longer
+// part of the SubjectPermission are dropped.
+func UpdateSubjectStatuses(statuses []managedv1alpha1.SubjectStatus, subjects []v1.Subject, clusterRoleBindings, roleBindings map[string]int) []managedv1alpha1.SubjectStatus {
+	previous := map[string]managedv1alpha1.SubjectStatus{}
+	for _, status := range statuses {
+		previous[SubjectKey(v1.Subject{Kind: status.Kind, Name: status.Name, Namespace: status.Namespace})] = status
+	}
+
+	var result []managedv1alpha1.SubjectStatus
+	for _, subject := range subjects {
+		key := SubjectKey(subject)
+		status := previous[key]
+		status.Kind = subject.Kind
+		status.Name = subject.Name
+		status.Namespace = subject.Namespace
+		if clusterRoleBindings != nil {
+			status.ClusterRoleBindings = clusterRoleBindings[key]
+		}
+		if roleBindings != nil {
+			status.RoleBindings = roleBindings[key]
+		}
+		result = append(result, status)
+	}
+	return result
+}
@@ -3,6 +3,7 @@ package k8sutil
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -51,6 +52,18 @@ func GetWatchNamespace() (string, error) {
 	return ns, nil
 }
 
+// GetWatchNamespaces returns the namespaces listed in WATCH_NAMESPACE, separated by commas.
+// No namespace is returned when it is unset or empty, which means every namespace is watched
+func GetWatchNamespaces() []string {
+	var namespaces []string
+	for _, ns := range strings.Split(os.Getenv(WatchNamespaceEnvVar), ",") {
+		if ns = strings.TrimSpace(ns); ns != "" && !slices.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
 // GetOperatorNamespace returns the namespace the operator should be running in.
 func GetOperatorNamespace() (string, error) {
 	if isRunModeLocal() {
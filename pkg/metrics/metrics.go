@@ -75,6 +75,15 @@ var (
 		"subject_name",
 	})
 
+	// ResourcesDeleted tracks deleted RBAC resources
+	ResourcesDeleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rbac_permissions_operator_resources_deleted_total",
+		Help: "Total number of RBAC resources deleted",
+	}, []string{
+		"resource_type",
+		"subject_name",
+	})
+
 	// ValidationFailures tracks validation failures
 	ValidationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "rbac_permissions_operator_validation_failures_total",
@@ -83,6 +92,33 @@ var (
 		"validation_type",
 	})
 
+	// PolicyViolations tracks the bindings withheld by the operator grant policy
+	PolicyViolations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rbac_permissions_operator_policy_violations_total",
+		Help: "Total number of SubjectPermission grants withheld by the operator policy",
+	}, []string{
+		"violation_type",
+	})
+
+	// SubjectPermissionExpiration tracks the time-bound SubjectPermissions, expiring or expired
+	SubjectPermissionExpiration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rbac_permissions_operator_expiration_timestamp_seconds",
+		Help: "Time the bindings of time-bound SubjectPermissions are revoked at, in seconds since the epoch",
+	}, []string{
+		"kind",
+		"namespace",
+		"subject_permission_name",
+		"state",
+	})
+
+	// Expirations tracks the SubjectPermissions whose bindings were revoked as they expired
+	Expirations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rbac_permissions_operator_expirations_total",
+		Help: "Total number of time-bound SubjectPermissions whose bindings were revoked as they expired",
+	}, []string{
+		"kind",
+	})
+
 	// MetricsList all metrics exported by this package
 	MetricsList = []prometheus.Collector{
 		RBACClusterwidePermissions,
@@ -91,31 +127,42 @@ var (
 		ReconcileTotal,
 		ReconcileErrors,
 		ResourcesCreated,
+		ResourcesDeleted,
 		ValidationFailures,
+		PolicyViolations,
+		SubjectPermissionExpiration,
+		Expirations,
 	}
 )
 
+// States of the time-bound SubjectPermissions in the expiration metric
+const (
+	ExpirationStateExpiring = "expiring"
+	ExpirationStateExpired  = "expired"
+)
+
 // DeletePrometheusMetric - Helper function to delete both clusterwide and
 // namespace permission metrics
-func DeletePrometheusMetric(gp *managedv1alpha1.SubjectPermission) {
+func DeletePrometheusMetric(gp managedv1alpha1.SubjectPermissionObject) {
 	deleteRBACClusterPermissionMetric(gp)
 	deleteRBACNamespacePermissionMetric(gp)
+	DeleteExpirationMetric(gp)
 }
 
 // AddPrometheusMetric - Helper function to add both clusterwide and namespace
 // permission metrics
-func AddPrometheusMetric(gp *managedv1alpha1.SubjectPermission) {
+func AddPrometheusMetric(gp managedv1alpha1.SubjectPermissionObject) {
 	addRBACClusterPermissionMetric(gp)
 	addRBACNamespacePermissionMetric(gp)
 }
 
 // addRBACClusterPermissionMetric - add a SubjectPermission to the exported data
 // Iterates through the ClusterPermissions
-func addRBACClusterPermissionMetric(gp *managedv1alpha1.SubjectPermission) {
-	for _, clusterPermissionName := range gp.Spec.ClusterPermissions {
+func addRBACClusterPermissionMetric(gp managedv1alpha1.SubjectPermissionObject) {
+	for _, clusterPermissionName := range gp.GetSpec().ClusterPermissions {
 		RBACClusterwidePermissions.With(prometheus.Labels{
-			"subject_name":            gp.Spec.SubjectName,
-			"subject_permission_name": gp.ObjectMeta.GetName(),
+			"subject_name":            gp.GetSpec().SubjectName,
+			"subject_permission_name": gp.GetName(),
 			"cluster_permission_name": clusterPermissionName,
 			"state":                   "1",
 		}).Set(1.0)
@@ -124,11 +171,11 @@ func addRBACClusterPermissionMetric(gp *managedv1alpha1.SubjectPermission) {
 
 // deleteRBACClusterPermissionMetric - delete a SubjectPermission from the
 // exported Prometheus data. Iterates through al the ClusterPermissions
-func deleteRBACClusterPermissionMetric(gp *managedv1alpha1.SubjectPermission) {
+func deleteRBACClusterPermissionMetric(gp managedv1alpha1.SubjectPermissionObject) {
 	var r bool
-	for _, clusterPermissionName := range gp.Spec.ClusterPermissions {
+	for _, clusterPermissionName := range gp.GetSpec().ClusterPermissions {
 		r = RBACClusterwidePermissions.DeleteLabelValues(
-			gp.Spec.SubjectName,
+			gp.GetSpec().SubjectName,
 			gp.GetName(),
 			clusterPermissionName,
 			"1",
@@ -136,20 +183,20 @@ func deleteRBACClusterPermissionMetric(gp *managedv1alpha1.SubjectPermission) {
 		// It's possible that we weren't able to delete the metric, so let's log a message to that effect.
 		if !r {
 			log.Info(fmt.Sprintf("Failed to delete GaugeVec labels: subject_name='%s', subject_permission_name='%s', cluster_permission='%s', state='1'",
-				gp.Spec.SubjectName, gp.GetName(), clusterPermissionName))
+				gp.GetSpec().SubjectName, gp.GetName(), clusterPermissionName))
 		}
 	}
 }
 
 // addRBACNamespacePermissionMetric - add a SubjectPermission to the exported data
 // Iterates through the ClusterPermissions
-func addRBACNamespacePermissionMetric(gp *managedv1alpha1.SubjectPermission) {
+func addRBACNamespacePermissionMetric(gp managedv1alpha1.SubjectPermissionObject) {
 
-	for _, permission := range gp.Spec.Permissions {
+	for _, permission := range gp.GetSpec().Permissions {
 		RBACNamespacePermissions.With(prometheus.Labels{
-			"subject_name":            gp.Spec.SubjectName,
-			"subject_permission_name": gp.ObjectMeta.GetName(),
-			"cluster_role_name":       permission.ClusterRoleName,
+			"subject_name":            gp.GetSpec().SubjectName,
+			"subject_permission_name": gp.GetName(),
+			"cluster_role_name":       permission.Role().String(),
 			"namespace_allow":         permission.NamespacesAllowedRegex,
 			"namespace_deny":          permission.NamespacesDeniedRegex,
 			"state":                   "1",
@@ -159,14 +206,14 @@ func addRBACNamespacePermissionMetric(gp *managedv1alpha1.SubjectPermission) {
 
 // deleteRBACNamespacePermissionMetric - delete a SubjectPermission from the
 // exported Prometheus data. Iterates through al the Permissions
-func deleteRBACNamespacePermissionMetric(gp *managedv1alpha1.SubjectPermission) {
+func deleteRBACNamespacePermissionMetric(gp managedv1alpha1.SubjectPermissionObject) {
 	var r bool
 
-	for _, permission := range gp.Spec.Permissions {
+	for _, permission := range gp.GetSpec().Permissions {
 		r = RBACNamespacePermissions.DeleteLabelValues(
-			gp.Spec.SubjectName,
+			gp.GetSpec().SubjectName,
 			gp.GetName(),
-			permission.ClusterRoleName,
+			permission.Role().String(),
 			permission.NamespacesAllowedRegex,
 			permission.NamespacesDeniedRegex,
 			"1",
@@ -174,7 +221,7 @@ func deleteRBACNamespacePermissionMetric(gp *managedv1alpha1.SubjectPermission)
 		// It's possible that we weren't able to delete the metric, so let's log a message to that effect.
 		if !r {
 			log.Info(fmt.Sprintf("Failed to delete GaugeVec labels: subject_name='%s', subject_permission_name='%s', cluster_permission='%s', state='1'",
-				gp.Spec.SubjectName, gp.GetName(), permission.ClusterRoleName))
+				gp.GetSpec().SubjectName, gp.GetName(), permission.Role()))
 		}
 	}
 }
@@ -209,7 +256,48 @@ func IncResourcesCreated(resourceType, subjectName string) {
 	ResourcesCreated.WithLabelValues(resourceType, subjectName).Inc()
 }
 
+// IncResourcesDeleted increments the resources deleted counter
+func IncResourcesDeleted(resourceType, subjectName string) {
+	ResourcesDeleted.WithLabelValues(resourceType, subjectName).Inc()
+}
+
 // IncValidationFailures increments the validation failure counter
 func IncValidationFailures(validationType string) {
 	ValidationFailures.WithLabelValues(validationType).Inc()
 }
+
+// IncPolicyViolations increments the policy violation counter
+func IncPolicyViolations(violationType string) {
+	PolicyViolations.WithLabelValues(violationType).Inc()
+}
+
+// SetExpirationMetric exports the expiration of a time-bound SubjectPermission, in the expired state once its
+// bindings were revoked
+func SetExpirationMetric(gp managedv1alpha1.SubjectPermissionObject, expiresAt time.Time, expired bool) {
+	state, previous := ExpirationStateExpiring, ExpirationStateExpired
+	if expired {
+		state, previous = previous, state
+	}
+	SubjectPermissionExpiration.DeleteLabelValues(kindLabel(gp), gp.GetNamespace(), gp.GetName(), previous)
+	SubjectPermissionExpiration.WithLabelValues(kindLabel(gp), gp.GetNamespace(), gp.GetName(), state).Set(float64(expiresAt.Unix()))
+}
+
+// DeleteExpirationMetric removes a SubjectPermission from the expiration metric, when it no longer expires or is deleted
+func DeleteExpirationMetric(gp managedv1alpha1.SubjectPermissionObject) {
+	for _, state := range []string{ExpirationStateExpiring, ExpirationStateExpired} {
+		SubjectPermissionExpiration.DeleteLabelValues(kindLabel(gp), gp.GetNamespace(), gp.GetName(), state)
+	}
+}
+
+// IncExpirations increments the expiration counter
+func IncExpirations(gp managedv1alpha1.SubjectPermissionObject) {
+	Expirations.WithLabelValues(kindLabel(gp)).Inc()
+}
+
+// kindLabel returns the kind of the SubjectPermission object for the labels of the metrics
+func kindLabel(gp managedv1alpha1.SubjectPermissionObject) string {
+	if _, ok := gp.(*managedv1alpha1.ClusterSubjectPermission); ok {
+		return "ClusterSubjectPermission"
+	}
+	return "SubjectPermission"
+}
@@ -55,9 +55,57 @@ func TestIncValidationFailures(t *testing.T) {
 	})
 }
 
+func TestIncPolicyViolations(t *testing.T) {
+	// Test that incrementing policy violation counters doesn't panic
+	assert.NotPanics(t, func() {
+		IncPolicyViolations("cluster_scope")
+		IncPolicyViolations("namespace_scope")
+	})
+}
+
+func TestExpirationMetric(t *testing.T) {
+	sp := &managedv1alpha1.SubjectPermission{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-sp-expiring",
+			Namespace: "test-namespace",
+		},
+	}
+	labels := func(state string) []string {
+		return []string{"SubjectPermission", "test-namespace", "test-sp-expiring", state}
+	}
+
+	SetExpirationMetric(sp, time.Now().Add(time.Hour), false)
+	assert.Equal(t, 1, collectedMetrics(SubjectPermissionExpiration))
+
+	// the expired state replaces the expiring one
+	SetExpirationMetric(sp, time.Now(), true)
+	assert.Equal(t, 1, collectedMetrics(SubjectPermissionExpiration))
+	assert.False(t, SubjectPermissionExpiration.DeleteLabelValues(labels(ExpirationStateExpiring)...))
+
+	DeletePrometheusMetric(sp)
+	assert.Equal(t, 0, collectedMetrics(SubjectPermissionExpiration))
+	assert.False(t, SubjectPermissionExpiration.DeleteLabelValues(labels(ExpirationStateExpired)...))
+}
+
+// collectedMetrics returns the number of metrics exported by the collector
+func collectedMetrics(collector prometheus.Collector) int {
+	ch := make(chan prometheus.Metric, 10)
+	collector.Collect(ch)
+	close(ch)
+	return len(ch)
+}
+
+func TestIncResourcesDeleted(t *testing.T) {
+	// Test that incrementing resource deletion counters doesn't panic
+	assert.NotPanics(t, func() {
+		IncResourcesDeleted("ClusterRoleBinding", "test-subject")
+		IncResourcesDeleted("RoleBinding", "another-subject")
+	})
+}
+
 func TestMetricsRegistration(t *testing.T) {
 	// Test that all metrics are properly defined in MetricsList
-	expectedMetrics := 7 // Original 2 + 5 new metrics
+	expectedMetrics := 11 // Original 2 + 9 new metrics
 	assert.Equal(t, expectedMetrics, len(MetricsList))
 
 	// Verify that all metrics in the list are valid Prometheus collectors
@@ -0,0 +1,249 @@
+package operatorconfig
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/openshift/rbac-permissions-operator/config"
+	controllerutil "github.com/openshift/rbac-permissions-operator/pkg/controllerutils"
+)
+
+// Keys of the operator ConfigMap, next to the grant policy keys of the controllerutils package
+const (
+	// MaxConcurrentReconcilesKey is the number of objects each controller reconciles at once, read at startup
+	MaxConcurrentReconcilesKey = "maxConcurrentReconciles"
+	// ResyncPeriodKey is the duration after which a reconciled SubjectPermission is reconciled again, such as 1h
+	ResyncPeriodKey = "resyncPeriod"
+	// MetricsPortKey is the port of the operator metrics endpoint, read at startup
+	MetricsPortKey = "metricsPort"
+	// FeaturesKey toggles optional behavior, as comma separated Name=true|false pairs
+	FeaturesKey = "features"
+)
+
+// Names of the features toggled with FeaturesKey
+const (
+	// FeatureAuthorReview rejects SubjectPermissions granting bindings their author could not create
+	FeatureAuthorReview = "AuthorReview"
+	// FeatureMissingClusterRoleWarnings warns at admission about ClusterRoles that do not exist
+	FeatureMissingClusterRoleWarnings = "MissingClusterRoleWarnings"
+)
+
+// Features toggles the optional behavior of the operator, every feature is enabled by default
+type Features struct {
+	AuthorReview               bool
+	MissingClusterRoleWarnings bool
+}
+
+// Config is the runtime configuration of the operator, read from the operator ConfigMap
+type Config struct {
+	// Policy limits what SubjectPermissions can grant, a nil Policy grants everything
+	Policy *controllerutil.GrantPolicy
+	// MaxConcurrentReconciles is the number of objects each controller reconciles at once
+	MaxConcurrentReconciles int
+	// ResyncPeriod is the duration after which a reconciled SubjectPermission is reconciled again, 0 disables it
+	ResyncPeriod time.Duration
+	// MetricsPort is the port of the operator metrics endpoint
+	MetricsPort string
+	// Features toggles optional behavior
+	Features Features
+}
+
+// Default returns the configuration used without the operator ConfigMap
+func Default() *Config {
+	return &Config{
+		MaxConcurrentReconciles: 1,
+		MetricsPort:             config.OperatorMetricsPort,
+		Features: Features{
+			AuthorReview:               true,
+			MissingClusterRoleWarnings: true,
+		},
+	}
+}
+
+// Parse reads the configuration from the data of the operator ConfigMap, keys that are not set keep their default.
+// Every problem found is returned as one error
+func Parse(data map[string]string) (*Config, error) {
+	cfg := Default()
+	var errs []string
+
+	policy, err := controllerutil.ParseGrantPolicy(data)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	cfg.Policy = policy
+
+	if value, ok := data[MaxConcurrentReconcilesKey]; ok {
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n < 1 {
+			errs = append(errs, fmt.Sprintf("invalid %s %q: must be a positive integer", MaxConcurrentReconcilesKey, value))
+		} else {
+			cfg.MaxConcurrentReconciles = n
+		}
+	}
+	if value, ok := data[ResyncPeriodKey]; ok {
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d < 0 {
+			errs = append(errs, fmt.Sprintf("invalid %s %q: must be a duration such as 1h", ResyncPeriodKey, value))
+		} else {
+			cfg.ResyncPeriod = d
+		}
+	}
+	if value, ok := data[MetricsPortKey]; ok {
+		port, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || port < 1 || port > 65535 {
+			errs = append(errs, fmt.Sprintf("invalid %s %q", MetricsPortKey, value))
+		} else {
+			cfg.MetricsPort = strconv.Itoa(port)
+		}
+	}
+	if err := parseFeatures(data[FeaturesKey], &cfg.Features); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return cfg, nil
+}
+
+// RestartRequired returns the keys changed between the two configurations that are only read at startup,
+// their new value takes effect once the operator restarts
+func RestartRequired(previous, current *Config) []string {
+	var keys []string
+	if previous.MaxConcurrentReconciles != current.MaxConcurrentReconciles {
+		keys = append(keys, MaxConcurrentReconcilesKey)
+	}
+	if previous.MetricsPort != current.MetricsPort {
+		keys = append(keys, MetricsPortKey)
+	}
+	return keys
+}
+
+// parseFeatures sets the features listed as comma separated Name=true|false pairs
+func parseFeatures(value string, features *Features) error {
+	toggles := map[string]*bool{
+		FeatureAuthorReview:               &features.AuthorReview,
+		FeatureMissingClusterRoleWarnings: &features.MissingClusterRoleWarnings,
+	}
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, enabled, found := strings.Cut(pair, "=")
+		toggle, known := toggles[strings.TrimSpace(name)]
+		if !found || !known {
+			return fmt.Errorf("invalid %s entry %q: must be one of %s or %s set to true or false", FeaturesKey, pair, FeatureAuthorReview, FeatureMissingClusterRoleWarnings)
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(enabled))
+		if err != nil {
+			return fmt.Errorf("invalid %s entry %q: %w", FeaturesKey, pair, err)
+		}
+		*toggle = b
+	}
+	return nil
+}
+
+// Store holds the configuration in effect and notifies its subscribers when it changes.
+// A nil or zero Store returns the default configuration, it is safe for concurrent use
+type Store struct {
+	mu          sync.RWMutex
+	cfg         *Config
+	data        map[string]string
+	subscribers []chan event.GenericEvent
+}
+
+// NewStore returns a Store holding the default configuration
+func NewStore() *Store {
+	return &Store{cfg: Default()}
+}
+
+// Get returns the configuration in effect, which must not be modified
+func (s *Store) Get() *Config {
+	if s == nil {
+		return Default()
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cfg == nil {
+		return Default()
+	}
+	return s.cfg
+}
+
+// Policy returns the grant policy in effect
+func (s *Store) Policy() *controllerutil.GrantPolicy {
+	return s.Get().Policy
+}
+
+// Subscribe returns a channel receiving an event for the operator ConfigMap each time the configuration changes,
+// to be watched by the controllers that re-queue their objects. Subscribers must be added before the first Update
+func (s *Store) Subscribe() <-chan event.GenericEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// a pending event already re-queues everything, so one is enough
+	ch := make(chan event.GenericEvent, 1)
+	s.subscribers = append(s.subscribers, ch)
+	return ch
+}
+
+// Update replaces the configuration with the one of the ConfigMap, or with the default one when the ConfigMap is nil.
+// An invalid ConfigMap leaves the configuration in effect unchanged. Returns true if the configuration changed
+func (s *Store) Update(configMap *corev1.ConfigMap) (bool, error) {
+	var data map[string]string
+	if configMap != nil {
+		data = configMap.Data
+	}
+	cfg, err := Parse(data)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cfg != nil && maps.Equal(s.data, data) {
+		return false, nil
+	}
+	s.cfg = cfg
+	s.data = maps.Clone(data)
+
+	obj := &corev1.ConfigMap{}
+	if configMap != nil {
+		obj = configMap.DeepCopy()
+	}
+	for _, ch := range s.subscribers {
+		select {
+		case ch <- event.GenericEvent{Object: obj}:
+		default:
+		}
+	}
+	return true, nil
+}
+
+// Load reads the ConfigMap with the client and updates the configuration with it. A missing ConfigMap keeps the
+// configuration in effect, the default one until a ConfigMap is loaded, so deleting the ConfigMap does not lift
+// the grant policy. Returns true if the configuration changed
+func (s *Store) Load(ctx context.Context, c client.Reader, key types.NamespacedName) (bool, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(ctx, key, configMap); err != nil {
+		if k8serr.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get ConfigMap %s: %w", key, err)
+	}
+	changed, err := s.Update(configMap)
+	if err != nil {
+		return false, fmt.Errorf("invalid configuration in ConfigMap %s: %w", key, err)
+	}
+	return changed, nil
+}
@@ -0,0 +1,128 @@
+package operatorconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	controllerutil "github.com/openshift/rbac-permissions-operator/pkg/controllerutils"
+	clientmocks "github.com/openshift/rbac-permissions-operator/pkg/util/test/generated/mocks/client"
+)
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse(map[string]string{
+		controllerutil.PolicyClusterScopeDeniedKey: "cluster-admin",
+		MaxConcurrentReconcilesKey:                 "4",
+		ResyncPeriodKey:                            "30m",
+		MetricsPortKey:                             "9090",
+		FeaturesKey:                                "AuthorReview=false",
+	})
+	require.NoError(t, err)
+	assert.False(t, cfg.Policy.PermitsClusterRole("cluster-admin"))
+	assert.Equal(t, 4, cfg.MaxConcurrentReconciles)
+	assert.Equal(t, 30*time.Minute, cfg.ResyncPeriod)
+	assert.Equal(t, "9090", cfg.MetricsPort)
+	assert.False(t, cfg.Features.AuthorReview)
+	assert.True(t, cfg.Features.MissingClusterRoleWarnings)
+}
+
+func TestParseDefaults(t *testing.T) {
+	cfg, err := Parse(nil)
+	require.NoError(t, err)
+	assert.Equal(t, Default().MaxConcurrentReconciles, cfg.MaxConcurrentReconciles)
+	assert.Equal(t, "8181", cfg.MetricsPort)
+	assert.Zero(t, cfg.ResyncPeriod)
+	assert.True(t, cfg.Policy.PermitsNamespace(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}}))
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse(map[string]string{
+		controllerutil.PolicyDeniedNamespacesKey: "(openshift-",
+		MaxConcurrentReconcilesKey:               "0",
+		ResyncPeriodKey:                          "hourly",
+		FeaturesKey:                              "Unknown=true",
+	})
+	require.Error(t, err)
+	for _, key := range []string{controllerutil.PolicyDeniedNamespacesKey, MaxConcurrentReconcilesKey, ResyncPeriodKey, FeaturesKey} {
+		assert.Contains(t, err.Error(), key)
+	}
+}
+
+func TestStoreUpdate(t *testing.T) {
+	store := NewStore()
+	changes := store.Subscribe()
+
+	changed, err := store.Update(&corev1.ConfigMap{Data: map[string]string{ResyncPeriodKey: "1h"}})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, time.Hour, store.Get().ResyncPeriod)
+	assert.Len(t, changes, 1)
+	<-changes
+
+	// the same data does not notify the subscribers again
+	changed, err = store.Update(&corev1.ConfigMap{Data: map[string]string{ResyncPeriodKey: "1h"}})
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Empty(t, changes)
+
+	// an invalid ConfigMap keeps the configuration in effect
+	_, err = store.Update(&corev1.ConfigMap{Data: map[string]string{ResyncPeriodKey: "hourly"}})
+	assert.Error(t, err)
+	assert.Equal(t, time.Hour, store.Get().ResyncPeriod)
+
+	// a nil ConfigMap restores the defaults
+	changed, err = store.Update(nil)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Zero(t, store.Get().ResyncPeriod)
+}
+
+func TestStoreLoad(t *testing.T) {
+	key := types.NamespacedName{Namespace: "openshift-rbac-permissions", Name: "rbac-permissions-operator"}
+	mockClient := clientmocks.NewMockClient(gomock.NewController(t))
+	store := NewStore()
+
+	mockClient.EXPECT().Get(gomock.Any(), key, gomock.Any()).Return(k8serr.NewNotFound(corev1.Resource("configmaps"), key.Name))
+	changed, err := store.Load(context.TODO(), mockClient, key)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	mockClient.EXPECT().Get(gomock.Any(), key, gomock.Any()).SetArg(2, corev1.ConfigMap{Data: map[string]string{MaxConcurrentReconcilesKey: "2"}})
+	changed, err = store.Load(context.TODO(), mockClient, key)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 2, store.Get().MaxConcurrentReconciles)
+
+	// a deleted ConfigMap keeps the last configuration loaded
+	mockClient.EXPECT().Get(gomock.Any(), key, gomock.Any()).Return(k8serr.NewNotFound(corev1.Resource("configmaps"), key.Name))
+	changed, err = store.Load(context.TODO(), mockClient, key)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, 2, store.Get().MaxConcurrentReconciles)
+}
+
+func TestRestartRequired(t *testing.T) {
+	current, err := Parse(map[string]string{
+		MaxConcurrentReconcilesKey: "4",
+		ResyncPeriodKey:            "30m",
+		MetricsPortKey:             "9090",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{MaxConcurrentReconcilesKey, MetricsPortKey}, RestartRequired(Default(), current))
+	assert.Empty(t, RestartRequired(current, current))
+}
+
+func TestNilStore(t *testing.T) {
+	var store *Store
+	assert.Equal(t, Default(), store.Get())
+	assert.Nil(t, store.Policy())
+}
@@ -18,6 +18,7 @@ import (
 	. "github.com/openshift/osde2e-common/pkg/gomega/matchers"
 	managedv1alpha1 "github.com/openshift/rbac-permissions-operator/api/v1alpha1"
 	"github.com/openshift/rbac-permissions-operator/config"
+	controllerutil "github.com/openshift/rbac-permissions-operator/pkg/controllerutils"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -119,13 +120,13 @@ func getSubjectPermissionRBACInfo(ctx context.Context, client *openshift.Client,
 
 	clusterRoleBindings := []string{}
 	for _, crName := range clusterRoles {
-		clusterRoleBindings = append(clusterRoleBindings, crName+"-"+us.Name)
+		clusterRoleBindings = append(clusterRoleBindings, controllerutil.BindingName(&us, crName, us.Spec.SubjectKind, "", us.Spec.SubjectName))
 	}
 
 	roleBindings := []string{}
 	for _, perm := range us.Spec.Permissions {
 		clusterRoles = append(clusterRoles, perm.ClusterRoleName)
-		roleBindings = append(roleBindings, perm.ClusterRoleName+"-"+us.Name)
+		roleBindings = append(roleBindings, controllerutil.BindingName(&us, perm.ClusterRoleName, us.Spec.SubjectKind, us.Spec.SubjectNamespace, us.Spec.SubjectName))
 	}
 	return clusterRoles, clusterRoleBindings, roleBindings
 }